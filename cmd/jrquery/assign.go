@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// assignIssue sets or clears the assignee of an issue.
+func assignIssue(client *jira.Client, flags *config.Flags) error {
+	ctx := context.Background()
+	issueKey := flags.Assign.Args.Issue
+
+	user, err := resolveUser(ctx, client, flags.Assign.Args.User)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		if err := client.AssignIssue(ctx, issueKey, ""); err != nil {
+			return err
+		}
+		fmt.Printf("%s is now unassigned\n", theme.Accent.Paint(issueKey))
+		return nil
+	}
+
+	if err := client.AssignIssue(ctx, issueKey, client.UserID(user)); err != nil {
+		return err
+	}
+	fmt.Printf("%s assigned to %s\n", theme.Accent.Paint(issueKey), theme.Secondary.Paint(user.DisplayName))
+	return nil
+}
+
+// resolveUser finds the Jira user for the given name, email or 'me'. It returns nil for 'none'.
+func resolveUser(ctx context.Context, client *jira.Client, query string) (*cloud.User, error) {
+	switch query {
+	case "none":
+		return nil, nil
+	case "me":
+		return client.GetCurrentUser(ctx)
+	}
+
+	users, err := client.GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	return users.Find(query)
+}
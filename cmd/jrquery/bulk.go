@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// bulkAction is a single action given with --do, applied to every issue of a query.
+type bulkAction struct {
+	Kind  string
+	Value string
+
+	// User ID of the assign action, resolved once before running it ("" to unassign)
+	userID string
+}
+
+// String returns the action as given in the command line.
+func (a bulkAction) String() string {
+	return a.Kind + ":" + a.Value
+}
+
+// bulkResult holds the outcome of the bulk actions on a single issue.
+type bulkResult struct {
+	Key string
+	Err error
+}
+
+// parseBulkActions parses the --do specs into actions.
+func parseBulkActions(specs []string) ([]bulkAction, error) {
+	var actions []bulkAction
+	for _, spec := range specs {
+		kind, value, ok := strings.Cut(spec, ":")
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid action %q, expected ACTION:VALUE", spec)
+		}
+
+		switch kind {
+		case "transition", "assign", "comment":
+		case "label":
+			if value == "+" || value == "-" {
+				return nil, fmt.Errorf("invalid action %q, missing label name", spec)
+			}
+		default:
+			return nil, fmt.Errorf("unknown action %q, available actions: transition, assign, label, comment", kind)
+		}
+		actions = append(actions, bulkAction{Kind: kind, Value: value})
+	}
+	return actions, nil
+}
+
+// runBulkActions previews the issues, asks for confirmation and applies the actions to all of them.
+func runBulkActions(client *jira.Client, issueList *jira.IssueList, flags *config.Flags) error {
+	ctx := context.Background()
+
+	actions, err := parseBulkActions(flags.Do)
+	if err != nil {
+		return err
+	}
+
+	// Resolve the users once instead of for every issue
+	for i, action := range actions {
+		if action.Kind != "assign" {
+			continue
+		}
+		user, err := resolveUser(ctx, client, action.Value)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			actions[i].userID = client.UserID(user)
+		}
+	}
+
+	if issueList.Count() == 0 {
+		fmt.Println("No results found.")
+		return nil
+	}
+
+	var names []string
+	for _, action := range actions {
+		names = append(names, action.String())
+	}
+
+	// Preview the affected issues
+	issueList.Print()
+	fmt.Println()
+	if flags.DryRun {
+		fmt.Printf("Would run %s on %s\n", theme.Secondary.Paint(strings.Join(names, ", ")), jira.Pluralize(issueList.Count(), "issue"))
+		return nil
+	}
+	if !flags.Yes && !confirm(fmt.Sprintf("Run %s on %s?", strings.Join(names, ", "), jira.Pluralize(issueList.Count(), "issue"))) {
+		return fmt.Errorf("aborted, use --yes to run without confirmation")
+	}
+
+	// Run the actions with at most flags.Concurrency issues in parallel
+	concurrency := max(flags.Concurrency, 1)
+	results := make([]bulkResult, issueList.Count())
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, issue := range issueList.Issues {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, key string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = bulkResult{Key: key, Err: applyBulkActions(ctx, client, key, actions)}
+		}(i, issue.Key)
+	}
+	wg.Wait()
+
+	// Print the summary in the same order as the preview
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("%s %s %v\n", theme.Failure.Paint("✘"), theme.Accent.Paint(result.Key), result.Err)
+			continue
+		}
+		fmt.Printf("%s %s\n", theme.Success.Paint("✔"), theme.Accent.Paint(result.Key))
+	}
+	fmt.Printf("\n%d succeeded, %d failed\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %s failed", failed, jira.Pluralize(len(results), "issue"))
+	}
+	return nil
+}
+
+// applyBulkActions runs all the actions on a single issue, stopping at the first error.
+func applyBulkActions(ctx context.Context, client *jira.Client, issueKey string, actions []bulkAction) error {
+	for _, action := range actions {
+		var err error
+		switch action.Kind {
+		case "transition":
+			err = bulkTransition(ctx, client, issueKey, action.Value)
+		case "assign":
+			err = client.AssignIssue(ctx, issueKey, action.userID)
+		case "label":
+			err = bulkLabel(ctx, client, issueKey, action.Value)
+		case "comment":
+			_, err = client.AddComment(ctx, issueKey, action.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", action, err)
+		}
+	}
+	return nil
+}
+
+// bulkTransition moves an issue through the transition matching status, which must not require any field.
+func bulkTransition(ctx context.Context, client *jira.Client, issueKey, status string) error {
+	transitions, err := client.GetTransitions(ctx, issueKey)
+	if err != nil {
+		return err
+	}
+	transition, err := transitions.Find(status)
+	if err != nil {
+		return err
+	}
+
+	if required := transition.RequiredFields(); len(required) > 0 {
+		var names []string
+		for _, id := range required {
+			names = append(names, transition.Fields[id].Name)
+		}
+		return fmt.Errorf("transition %s requires fields %s, use the transition command instead", transition.Name, strings.Join(names, ", "))
+	}
+
+	return client.DoTransition(ctx, issueKey, transition, nil, "")
+}
+
+// bulkLabel adds (+NAME or NAME) or removes (-NAME) comma separated labels of an issue.
+func bulkLabel(ctx context.Context, client *jira.Client, issueKey, value string) error {
+	var operations []map[string]any
+	for _, label := range strings.Split(value, ",") {
+		operation := "add"
+		label = strings.TrimSpace(label)
+		switch {
+		case strings.HasPrefix(label, "+"):
+			label = label[1:]
+		case strings.HasPrefix(label, "-"):
+			operation = "remove"
+			label = label[1:]
+		}
+		if label != "" {
+			operations = append(operations, map[string]any{operation: label})
+		}
+	}
+
+	return client.EditIssue(ctx, issueKey, map[string]any{
+		"update": map[string]any{"labels": operations},
+	})
+}
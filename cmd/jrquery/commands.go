@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// runCommand executes the subcommand selected in the command line.
+func runCommand(client *jira.Client, cfg *config.Config, flags *config.Flags) error {
+	switch flags.Command {
+	case "show":
+		return showIssue(client, cfg, flags)
+	case "comments":
+		return listComments(client, flags)
+	case "comment":
+		return addComment(client, flags)
+	case "transition":
+		return transitionIssue(client, flags)
+	case "assign":
+		return assignIssue(client, flags)
+	case "create":
+		return createIssue(client, cfg, flags)
+	case "edit":
+		return editIssue(client, flags)
+	case "log":
+		return logWork(client, flags)
+	case "worklogs":
+		return listWorklogs(client, flags)
+	case "timesheet":
+		return showTimesheet(client, flags)
+	case "presets list":
+		return listPresets(cfg)
+	case "presets add":
+		return addPreset(cfg, flags.Presets.Add)
+	case "presets rm":
+		return removePreset(cfg, flags.Presets.Rm)
+	case "profiles":
+		return listProfiles(cfg)
+	}
+
+	return fmt.Errorf("unknown command: %s", flags.Command)
+}
+
+// showIssue prints the full details of a single issue.
+func showIssue(client *jira.Client, cfg *config.Config, flags *config.Flags) error {
+	issue, err := client.GetIssue(context.Background(), flags.Show.Args.Issue)
+	if err != nil {
+		return err
+	}
+
+	detail := jira.NewIssueDetail(issue, cfg.BrowseURL(issue.Key))
+
+	// Prefer the rich text description when the instance provides it
+	description, err := client.GetIssueDescription(context.Background(), issue.Key)
+	if err != nil {
+		if flags.Debug {
+			fmt.Fprintf(os.Stderr, "Unable to fetch rich text description: %v\n", err)
+		}
+	} else {
+		detail.Description = description
+	}
+
+	return detail.Output(flags.Output)
+}
+
+// listComments prints all the comments of an issue.
+func listComments(client *jira.Client, flags *config.Flags) error {
+	comments, err := client.GetComments(context.Background(), flags.Comments.Args.Issue)
+	if err != nil {
+		return err
+	}
+
+	return comments.Output(flags.Output)
+}
+
+// addComment posts a new comment to an issue.
+func addComment(client *jira.Client, flags *config.Flags) error {
+	issueKey := flags.Comment.Args.Issue
+
+	body, err := readText(flags.Comment.Message, fmt.Sprintf("Enter the comment for %s.", issueKey))
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		return fmt.Errorf("aborting due to empty comment")
+	}
+
+	comment, err := client.AddComment(context.Background(), issueKey, body)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added comment %s to %s\n", theme.Accent.Paint(comment.ID), theme.Accent.Paint(issueKey))
+	return nil
+}
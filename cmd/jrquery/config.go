@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// runConfigCommand executes the config subcommands. They run before loading the
+// configuration, so they also work when it is missing or broken.
+func runConfigCommand(flags *config.Flags) error {
+	switch flags.Command {
+	case "config show":
+		return showConfig(flags)
+	case "config get":
+		value, err := config.GetSetting(flags.Profile, flags.Config.Get.Args.Key)
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	case "config set":
+		return setConfig(flags)
+	case "config edit":
+		return editConfig(flags)
+	case "config validate":
+		return validateConfig(flags)
+	}
+
+	return fmt.Errorf("unknown command: %s", flags.Command)
+}
+
+// showConfig prints the configuration file path and the settings of the profile, with the
+// secrets masked.
+func showConfig(flags *config.Flags) error {
+	path, err := config.ConfigPath()
+	if err != nil {
+		return err
+	}
+	profile, settings, err := config.Settings(flags.Profile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Configuration file %s\n", theme.Title.Paint(path))
+	fmt.Printf("Profile %s\n", theme.Accent.Paint(profile))
+	if len(settings) == 0 {
+		fmt.Println("No settings found, run 'jrquery login' to configure the profile.")
+		return nil
+	}
+	fmt.Println()
+
+	// Determine the maximum width for keys
+	keyWidth := 0
+	for _, setting := range settings {
+		keyWidth = max(keyWidth, len(setting.Key))
+	}
+
+	for _, setting := range settings {
+		fmt.Printf("%s %s", theme.Accent.Paintf("%-*s", keyWidth, setting.Key), setting.Value)
+		if setting.Source != "" {
+			fmt.Printf(" %s", theme.Secondary.Paintf("(%s)", setting.Source))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// setConfig changes the value of a configuration key of the profile.
+func setConfig(flags *config.Flags) error {
+	key, value := strings.ToLower(flags.Config.Set.Args.Key), flags.Config.Set.Args.Value
+	if err := config.SetSetting(flags.Profile, key, value); err != nil {
+		return err
+	}
+
+	if key == "jira.api_token" {
+		value = config.MaskSecret(value)
+	}
+	fmt.Printf("Set %s to %s\n", theme.Accent.Paint(key), value)
+	return nil
+}
+
+// editConfig opens the configuration file in the user's editor and checks it afterwards.
+func editConfig(flags *config.Flags) error {
+	if !isTerminal(os.Stdin) {
+		return fmt.Errorf("stdin is not a terminal, use 'jrquery config set' instead")
+	}
+	path, err := config.ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	// Create an empty configuration to edit, only readable by the user
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("could not create configuration directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+			return fmt.Errorf("could not create configuration file: %w", err)
+		}
+	}
+	if err := runEditor(path); err != nil {
+		return err
+	}
+	// Some editors replace the file instead of writing it
+	if err := os.Chmod(path, 0o600); err != nil {
+		return err
+	}
+
+	if _, err := config.LoadConfig(flags.Profile); err != nil && !errors.Is(err, config.ErrNotConfigured) {
+		return fmt.Errorf("the configuration file has errors, run 'jrquery config edit' again: %w", err)
+	}
+	return nil
+}
+
+// validateConfig checks the credentials of the profile, printing the authenticated user,
+// the type of the Jira instance and the scopes of the token.
+func validateConfig(flags *config.Flags) error {
+	cfg, err := config.LoadConfig(flags.Profile)
+	if err != nil {
+		return err
+	}
+	if err := applyStatusStyles(cfg); err != nil {
+		return fmt.Errorf("error applying status styles: %w", err)
+	}
+	client, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), profileTestTimeout)
+	defer cancel()
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("could not validate the credentials of profile %q: %w", cfg.Profile, err)
+	}
+
+	printField := func(label, value string) {
+		fmt.Printf("%s %s\n", theme.Accent.Paintf("%-9s", label), value)
+	}
+	printField("Profile", cfg.Profile)
+	printField("URL", cfg.JiraBaseURL)
+
+	// The instance type is informative, a failure does not invalidate the credentials
+	info, err := client.GetServerInfo(ctx)
+	if err != nil {
+		printField("Instance", theme.Failure.Paintf("✘ %v", err))
+	} else {
+		printField("Instance", instanceName(info))
+		if isCloud := info.DeploymentType == "Cloud"; isCloud == cfg.IsServer() {
+			deployment := config.DeploymentCloud
+			if !isCloud {
+				deployment = config.DeploymentServer
+			}
+			printField("", theme.Secondary.Paintf("the profile is configured as %s, run 'jrquery config set jira.deployment %s'", cfg.Deployment, deployment))
+		}
+	}
+
+	name := user.DisplayName
+	if user.EmailAddress != "" {
+		name += " <" + user.EmailAddress + ">"
+	}
+	printField("User", fmt.Sprintf("%s (%s)", name, client.UserID(user)))
+
+	switch {
+	case cfg.Auth == config.AuthOAuth:
+		printField("Auth", "OAuth 2.0, client "+cfg.OAuth.ClientID)
+		printField("Scopes", oauthScopes(ctx, cfg))
+	case cfg.IsServer():
+		printField("Auth", "Personal Access Token")
+		printField("Scopes", "none, the token has the permissions of the user")
+	default:
+		printField("Auth", "API token")
+		printField("Scopes", "none, the token has the permissions of the user")
+	}
+
+	fmt.Println()
+	fmt.Printf("%s The credentials of profile %s are valid\n", theme.Success.Paint("✔"), theme.Accent.Paint(cfg.Profile))
+	return nil
+}
+
+// instanceName returns the product name and version of a Jira instance.
+func instanceName(info *jira.ServerInfo) string {
+	switch info.DeploymentType {
+	case "Cloud":
+		return "Jira Cloud"
+	case "DataCenter":
+		return "Jira Data Center " + info.Version
+	}
+	return strings.TrimSpace("Jira " + info.DeploymentType + " " + info.Version)
+}
+
+// oauthScopes returns the scopes granted to the OAuth 2.0 authorization of a profile in its
+// site, warning about the missing ones.
+func oauthScopes(ctx context.Context, cfg *config.Config) string {
+	resources, err := jira.GetOAuthResources(ctx, oauthAPIURL(cfg), cfg.OAuth.Token)
+	if err != nil {
+		return theme.Failure.Paintf("✘ %v", err)
+	}
+
+	for _, site := range resources {
+		if site.ID != cfg.OAuth.CloudID {
+			continue
+		}
+		scopes := strings.Join(site.Scopes, ", ")
+		var missing []string
+		for _, scope := range jira.OAuthScopes {
+			// offline_access only grants the refresh token, it is not a site scope
+			if scope != "offline_access" && !slices.Contains(site.Scopes, scope) {
+				missing = append(missing, scope)
+			}
+		}
+		if len(missing) > 0 {
+			scopes += " " + theme.Secondary.Paintf("(missing %s)", strings.Join(missing, ", "))
+		}
+		return scopes
+	}
+	return theme.Failure.Paintf("✘ site %s is no longer authorized", cfg.OAuth.CloudID)
+}
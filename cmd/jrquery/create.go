@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// createIssue creates a new issue from the command line flags, prompting for missing basic fields.
+func createIssue(client *jira.Client, cfg *config.Config, flags *config.Flags) error {
+	ctx := context.Background()
+	opts := flags.Create
+
+	// Select the project from the visible ones when not given and the profile has no default
+	if opts.Project == "" {
+		opts.Project = cfg.Project
+	}
+	if opts.Project == "" {
+		projects, err := client.GetAllProjects()
+		if err != nil {
+			return err
+		}
+		var keys []string
+		for _, project := range projects.Projects {
+			keys = append(keys, project.Key)
+		}
+		if opts.Project, err = promptChoice("Project", keys); err != nil {
+			return err
+		}
+	}
+
+	// Select the issue type from the ones available in the project
+	types, err := client.GetCreateIssueTypes(ctx, opts.Project)
+	if err != nil {
+		return err
+	}
+	if opts.Type == "" {
+		var names []string
+		for _, issueType := range types {
+			names = append(names, issueType.Name)
+		}
+		if opts.Type, err = promptChoice("Issue type", names); err != nil {
+			return err
+		}
+	}
+	issueType, err := jira.FindIssueType(types, opts.Type)
+	if err != nil {
+		return err
+	}
+
+	meta, err := client.GetCreateMeta(ctx, opts.Project, *issueType)
+	if err != nil {
+		return err
+	}
+
+	if opts.Summary == "" {
+		if opts.Summary, err = prompt("Summary"); err != nil {
+			return err
+		}
+	}
+
+	// Description from the flag, stdin or the editor
+	description := opts.Description
+	if description == "" && !isTerminal(os.Stdin) {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("error reading description from stdin: %w", err)
+		}
+		description = strings.TrimSpace(string(data))
+	} else if description == "" && opts.Edit {
+		if description, err = editText(fmt.Sprintf("Enter the description for the new %s.", issueType.Name)); err != nil {
+			return err
+		}
+	}
+
+	fields := map[string]any{
+		"project":   map[string]string{"key": opts.Project},
+		"issuetype": map[string]string{"id": issueType.ID},
+		"summary":   opts.Summary,
+	}
+	if description != "" {
+		fields["description"] = description
+	}
+	if len(opts.Labels) > 0 {
+		if err := meta.Set(fields, "labels", strings.Join(opts.Labels, ",")); err != nil {
+			return err
+		}
+	}
+	if len(opts.Components) > 0 {
+		if err := meta.Set(fields, "components", strings.Join(opts.Components, ",")); err != nil {
+			return err
+		}
+	}
+	if opts.Priority != "" {
+		if err := meta.Set(fields, "priority", opts.Priority); err != nil {
+			return err
+		}
+	}
+	if opts.Parent != "" {
+		fields["parent"] = map[string]string{"key": opts.Parent}
+	}
+	if opts.Assignee != "" {
+		user, err := resolveUser(ctx, client, opts.Assignee)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			fields["assignee"] = client.UserRef(client.UserID(user))
+		}
+	}
+
+	// Any other field given by ID or display name
+	for _, field := range opts.Fields {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("invalid field %q, expected NAME=VALUE", field)
+		}
+		name = strings.TrimSpace(name)
+
+		// User fields accept names and emails, which are resolved to account IDs
+		if value, err = resolveUserInput(ctx, client, meta, name, value); err != nil {
+			return err
+		}
+		if err := meta.Set(fields, name, value); err != nil {
+			return err
+		}
+	}
+
+	if err := meta.Validate(fields); err != nil {
+		return err
+	}
+
+	issue, err := client.CreateIssue(ctx, fields)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s %s\n", theme.Accent.Paint(issue.Key), cfg.BrowseURL(issue.Key))
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// editIssue applies the --set, --add and --remove changes to an issue.
+func editIssue(client *jira.Client, flags *config.Flags) error {
+	ctx := context.Background()
+	opts := flags.Edit
+	issueKey := opts.Args.Issue
+
+	if len(opts.Set)+len(opts.Add)+len(opts.Remove) == 0 {
+		return fmt.Errorf("nothing to edit, use --set, --add or --remove")
+	}
+
+	meta, err := client.GetEditMeta(ctx, issueKey)
+	if err != nil {
+		return err
+	}
+	allFields, err := client.GetFields(ctx)
+	if err != nil {
+		return err
+	}
+	edit := jira.NewIssueEdit(meta, allFields)
+
+	changes := []struct {
+		values []string
+		apply  func(name, input string) error
+	}{
+		{opts.Set, edit.Set},
+		{opts.Add, edit.Add},
+		{opts.Remove, edit.Remove},
+	}
+	for _, change := range changes {
+		for _, value := range change.values {
+			name, input, ok := strings.Cut(value, "=")
+			if !ok {
+				return fmt.Errorf("invalid change %q, expected NAME=VALUE", value)
+			}
+
+			// User fields accept names and emails, which are resolved to account IDs
+			if input, err = resolveUserInput(ctx, client, edit, name, input); err != nil {
+				return err
+			}
+			if err := change.apply(name, input); err != nil {
+				return err
+			}
+		}
+	}
+
+	payload := edit.Payload()
+	if opts.DryRun {
+		data, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error converting payload to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if err := client.EditIssue(ctx, issueKey, payload); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s\n", theme.Accent.Paint(issueKey))
+	return nil
+}
+
+// fieldLookup finds the metadata of a field by ID or display name, like the create and edit metadata.
+type fieldLookup interface {
+	Field(name string) (string, jira.FieldMeta, error)
+}
+
+// resolveUserInput replaces the names, emails and "me" in the input of a user field by the
+// IDs of the users. Inputs of other fields are returned unchanged.
+func resolveUserInput(ctx context.Context, client *jira.Client, fields fieldLookup, name, input string) (string, error) {
+	_, field, err := fields.Field(name)
+	if err != nil {
+		return "", err
+	}
+	if field.Schema.Type != "user" && field.Schema.Items != "user" {
+		return input, nil
+	}
+
+	var ids []string
+	for _, query := range strings.Split(input, ",") {
+		user, err := resolveUser(ctx, client, strings.TrimSpace(query))
+		if err != nil {
+			return "", err
+		}
+		if user == nil {
+			return "", fmt.Errorf("field %s cannot be set to none", field.Name)
+		}
+		ids = append(ids, client.UserID(user))
+	}
+	return strings.Join(ids, ","), nil
+}
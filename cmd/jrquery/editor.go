@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// editorComment is the help line added to the temporary file opened in the editor.
+const editorComment = "# Lines starting with '#' will be ignored. An empty message aborts the operation."
+
+// readText returns the text given in the command line, or reads it from stdin when it
+// is not a terminal, or from the user's editor otherwise.
+func readText(text, hint string) (string, error) {
+	if text != "" {
+		return text, nil
+	}
+
+	// Read from stdin when piped
+	if !isTerminal(os.Stdin) {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("error reading from stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	return editText(hint)
+}
+
+// editText opens the user's editor ($VISUAL, $EDITOR or vi) and returns the entered text.
+func editText(hint string) (string, error) {
+	file, err := os.CreateTemp("", "jrquery-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	fmt.Fprintf(file, "\n%s\n# %s\n", editorComment, hint)
+	file.Close()
+
+	if err := runEditor(file.Name()); err != nil {
+		return "", err
+	}
+
+	file, err = os.Open(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("error reading temporary file: %w", err)
+	}
+	defer file.Close()
+
+	// Remove the comment lines
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if !strings.HasPrefix(scanner.Text(), "#") {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading temporary file: %w", err)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+// runEditor opens the given file in the user's editor ($VISUAL, $EDITOR or vi) and waits
+// for it to exit.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The editor variable may include arguments, so run it through the shell
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error running editor %s: %w", editor, err)
+	}
+	return nil
+}
+
+// isTerminal returns true if the given file is a terminal. Other character devices,
+// such as /dev/null, are not.
+func isTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
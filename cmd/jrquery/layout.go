@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+)
+
+// defaultColumns is the name of the column preset used for the text output when neither
+// --columns nor --format are given.
+const defaultColumns = "default"
+
+// issueLayout is how the issues are printed, given by --columns, --format and --group-by,
+// or the dimensions of their statistics, given by --stats and --stats-by.
+type issueLayout struct {
+	columns  []jira.IssueColumn
+	template *template.Template
+	groupBy  *jira.IssueColumn
+	stats    []jira.StatsDimension
+}
+
+// apply sets the layout of the issue list.
+func (l *issueLayout) apply(issueList *jira.IssueList) {
+	issueList.Columns = l.columns
+	issueList.Template = l.template
+	issueList.GroupBy = l.groupBy
+}
+
+// parseIssueLayout parses the columns or the template given with --columns and --format,
+// the column of --group-by and the dimensions of --stats-by. The custom fields are only
+// fetched if they are needed.
+func parseIssueLayout(client *jira.Client, cfg *config.Config, flags *config.Flags) (*issueLayout, error) {
+	var fields *jira.FieldList
+	loadFields := func() (*jira.FieldList, error) {
+		if fields != nil {
+			return fields, nil
+		}
+		var err error
+		if fields, err = client.GetFields(context.Background()); err != nil {
+			return nil, fmt.Errorf("error fetching fields: %w", err)
+		}
+		return fields, nil
+	}
+
+	layout := &issueLayout{}
+	if flags.Stats || len(flags.StatsBy) > 0 {
+		names := flags.StatsBy
+		if len(names) == 0 {
+			names = jira.DefaultStatsDimensions
+		}
+		dimensions, err := jira.ParseStatsDimensions(names, loadFields)
+		if err != nil {
+			return nil, err
+		}
+		layout.stats = dimensions
+		return layout, nil
+	}
+
+	if flags.GroupBy != "" {
+		columns, err := jira.ParseIssueColumns(flags.GroupBy, loadFields)
+		if err != nil {
+			return nil, err
+		}
+		layout.groupBy = &columns[0]
+	}
+
+	if flags.Format != "" {
+		if flags.Columns != "" {
+			return nil, fmt.Errorf("--format and --columns cannot be used together")
+		}
+		if flags.Output != "" && flags.Output != jira.FormatText {
+			return nil, fmt.Errorf("--format can only be used with the text output")
+		}
+		tmpl, err := jira.NewIssueTemplate(flags.Format, loadFields)
+		if err != nil {
+			return nil, err
+		}
+		layout.template = tmpl
+		return layout, nil
+	}
+
+	spec := flags.Columns
+	if spec == "" {
+		if flags.Output != "" && flags.Output != jira.FormatText {
+			return layout, nil
+		}
+		spec = defaultColumns
+	}
+	if preset := cfg.Columns[strings.ToLower(spec)]; preset != "" {
+		spec = preset
+	} else if spec == defaultColumns {
+		return layout, nil
+	}
+
+	columns, err := jira.ParseIssueColumns(spec, loadFields)
+	if err != nil {
+		return nil, err
+	}
+	layout.columns = columns
+	return layout, nil
+}
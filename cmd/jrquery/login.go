@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// oauthLoginTimeout limits the time waiting for the user to authorize jrquery in the browser.
+const oauthLoginTimeout = 5 * time.Minute
+
+// login configures the credentials of the profile, prompting for an API token or running
+// the OAuth 2.0 authorization in the browser.
+func login(flags *config.Flags) error {
+	if !isTerminal(os.Stdin) {
+		return fmt.Errorf("stdin is not a terminal, set the credentials with 'jrquery config set' instead")
+	}
+	if !flags.Login.OAuth {
+		return config.PromptConfig(flags.Profile)
+	}
+
+	cfg, err := config.PromptOAuth(flags.Profile, flags.Login.ClientID, flags.Login.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oauthLoginTimeout)
+	defer cancel()
+	token, err := jira.OAuthLogin(ctx, oauthConfig(cfg, flags.Login.RedirectURL), func(authURL string) {
+		fmt.Println("Open the following URL to authorize jrquery, waiting for the callback...")
+		fmt.Println()
+		fmt.Println(theme.Title.Paint(authURL))
+		fmt.Println()
+		exec.Command("xdg-open", authURL).Start()
+	})
+	if err != nil {
+		return err
+	}
+
+	resources, err := jira.GetOAuthResources(ctx, oauthAPIURL(cfg), token)
+	if err != nil {
+		return err
+	}
+	site, err := selectOAuthSite(resources, cfg.JiraBaseURL)
+	if err != nil {
+		return err
+	}
+
+	cfg.JiraBaseURL = site.URL
+	cfg.OAuth.CloudID = site.ID
+	cfg.OAuth.Token = token
+	if err := config.SaveOAuth(cfg); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+
+	fmt.Printf("Authorized %s (%s)\n", theme.Accent.Paint(site.Name), site.URL)
+	return nil
+}
+
+// selectOAuthSite returns the authorized site with the given URL, the only one authorized or
+// the one chosen by the user.
+func selectOAuthSite(sites []jira.OAuthResource, baseURL string) (*jira.OAuthResource, error) {
+	if len(sites) == 0 {
+		return nil, fmt.Errorf("no Jira site was authorized")
+	}
+	for i, site := range sites {
+		if baseURL != "" && strings.TrimSuffix(site.URL, "/") == strings.TrimSuffix(baseURL, "/") {
+			return &sites[i], nil
+		}
+	}
+	if len(sites) == 1 {
+		return &sites[0], nil
+	}
+
+	for i, site := range sites {
+		fmt.Printf("%d) %s %s\n", i+1, theme.Accent.Paint(site.Name), site.URL)
+	}
+	fmt.Print("Select the Jira site: ")
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	index, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || index < 1 || index > len(sites) {
+		return nil, fmt.Errorf("invalid site %q", strings.TrimSpace(input))
+	}
+	return &sites[index-1], nil
+}
+
+// oauthConfig returns the OAuth 2.0 configuration of a profile, with the Atlassian endpoints
+// unless others are configured.
+func oauthConfig(cfg *config.Config, redirectURL string) *oauth2.Config {
+	authURL, tokenURL := cfg.OAuth.AuthURL, cfg.OAuth.TokenURL
+	if authURL == "" {
+		authURL = jira.OAuthAuthURL
+	}
+	if tokenURL == "" {
+		tokenURL = jira.OAuthTokenURL
+	}
+	return jira.NewOAuthConfig(cfg.OAuth.ClientID, cfg.OAuth.ClientSecret, authURL, tokenURL, redirectURL)
+}
+
+// oauthAPIURL returns the URL of the API gateway of a profile authorized with OAuth 2.0.
+func oauthAPIURL(cfg *config.Config) string {
+	if cfg.OAuth.APIURL == "" {
+		return jira.OAuthAPIURL
+	}
+	return cfg.OAuth.APIURL
+}
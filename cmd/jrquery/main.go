@@ -1,12 +1,17 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"os/exec"
+	"strings"
 
+	"golang.org/x/oauth2"
 	"irontec.com/jrquery/config"
 	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
 )
 
 var Version = "development"
@@ -19,9 +24,14 @@ func main() {
 		log.Fatalf("error parsing flags: %v", err)
 	}
 
+	// Disable the colors when requested or not supported by the output
+	if err := theme.SetMode(flags.Color); err != nil {
+		log.Fatalf("error parsing flags: %v", err)
+	}
+
 	// If the --version flag is set, print the version and exit
 	if flags.Version {
-		fmt.Printf("\033[1;36mjrquery\033[0m (%s) - Jira Issues query tool [%s]\n\n", Version, Commit)
+		fmt.Printf("%s (%s) - Jira Issues query tool [%s]\n\n", theme.Banner.Paint("jrquery"), Version, Commit)
 
 		fmt.Println("Copyright (C) 2024 Irontec S.L.")
 		fmt.Println("Licenced under GPLv3+: GNU GPL version 3 or greater.")
@@ -30,45 +40,86 @@ func main() {
 		return
 	}
 
+	// Configure the credentials of the profile
+	if flags.Command == "login" {
+		if err := login(flags); err != nil {
+			log.Fatalf("error running login: %v", err)
+		}
+		return
+	}
+
+	// Show or change the configuration, which may be missing or broken
+	if strings.HasPrefix(flags.Command, "config ") {
+		if err := runConfigCommand(flags); err != nil {
+			log.Fatalf("error running %s: %v", flags.Command, err)
+		}
+		return
+	}
+
 	// Load configuration
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadConfig(flags.Profile)
+	if err != nil && !errors.Is(err, config.ErrNotConfigured) {
+		log.Fatalf("error obtaining config: %v", err)
+	}
 	if err != nil {
+		// The credentials can only be prompted in a terminal
+		if !isTerminal(os.Stdin) {
+			log.Fatalf("error obtaining config: %v; stdin is not a terminal to prompt for the credentials, configure them with 'jrquery login' or 'jrquery config set'", err)
+		}
+
 		// Check if all config values are present; if not, prompt user and save
-		if err := config.PromptConfig(); err != nil {
+		if err := config.PromptConfig(flags.Profile); err != nil {
 			log.Fatalf("error saving config: %v", err)
 		}
 
 		// Try again loading the config
-		cfg, err = config.LoadConfig()
+		cfg, err = config.LoadConfig(flags.Profile)
 		if err != nil {
 			log.Fatalf("error obtaining config: %v", err)
 		}
 	}
 
+	// Apply the colors of the status categories
+	if err := applyStatusStyles(cfg); err != nil {
+		log.Fatalf("error applying status styles: %v", err)
+	}
+
+	// Apply the query preset given with --preset or @NAME
+	flags, searchTerms, err = applyPreset(cfg, flags, searchTerms)
+	if err != nil {
+		log.Fatalf("error applying preset: %v", err)
+	}
+	jira.SetWide(flags.Wide)
+
 	if flags.Open != "" {
-		cmd := exec.Command("xdg-open", fmt.Sprintf("%s/browse/%s", cfg.JiraBaseURL, flags.Open))
+		cmd := exec.Command("xdg-open", cfg.BrowseURL(flags.Open))
 		cmd.Run()
 		return
 	}
 
-	// Handle 'me' as username
-	if flags.Username == "me" {
-		flags.Username = cfg.JiraUserEmail
-	}
-
 	// Initialize Jira client with loaded config
-	client, err := jira.NewClient(cfg.JiraBaseURL, cfg.JiraAPIToken, cfg.JiraUserEmail)
+	client, err := newClient(cfg)
 	if err != nil {
 		log.Fatalf("error initializing Jira client: %v", err)
 	}
 
+	// Run the selected subcommand, if any
+	if flags.Command != "" {
+		if err := runCommand(client, cfg, flags); err != nil {
+			log.Fatalf("error running %s: %v", flags.Command, err)
+		}
+		return
+	}
+
 	// List projects
 	if flags.ListProjects {
 		projects, err := client.GetAllProjects()
 		if err != nil {
 			log.Fatalf("Error fetching projects: %v", err)
 		}
-		projects.Print()
+		if err := projects.Output(flags.Output); err != nil {
+			log.Fatalf("Error printing projects: %v", err)
+		}
 		return
 	}
 
@@ -78,7 +129,9 @@ func main() {
 		if err != nil {
 			log.Fatalf("Error fetching users: %v", err)
 		}
-		users.Print()
+		if err := users.Output(flags.Output); err != nil {
+			log.Fatalf("Error printing users: %v", err)
+		}
 		return
 	}
 
@@ -88,7 +141,9 @@ func main() {
 		if err != nil {
 			log.Fatalf("Error fetching filters: %v", err)
 		}
-		filters.Print()
+		if err := filters.Output(flags.Output); err != nil {
+			log.Fatalf("Error printing filters: %v", err)
+		}
 		return
 	}
 
@@ -102,11 +157,25 @@ func main() {
 		return
 	}
 
+	// Check the bulk actions before running the query
+	if _, err := parseBulkActions(flags.Do); err != nil {
+		log.Fatalf("error parsing bulk actions: %v", err)
+	}
+
 	// Build JQL query from flags
-	builder := jira.NewQueryBuilder()
-	jqlQuery := builder.BuildJQLQuery(flags, searchTerms)
+	builder := jira.NewQueryBuilder().SetEpicField(client.EpicField())
+	jqlQuery, err := builder.BuildJQLQuery(flags, searchTerms)
+	if err != nil {
+		log.Fatalf("error building query: %v", err)
+	}
 	if flags.Debug {
-		fmt.Printf("Searching issues for JQL: %s\n", jqlQuery)
+		fmt.Fprintf(os.Stderr, "Searching issues for JQL: %s\n", jqlQuery)
+	}
+
+	// Parse the columns or template of the issues before running the query
+	layout, err := parseIssueLayout(client, cfg, flags)
+	if err != nil {
+		log.Fatalf("error parsing issue layout: %v", err)
 	}
 
 	var issueList *jira.IssueList
@@ -128,6 +197,51 @@ func main() {
 		return
 	}
 
+	// Print the counts of the issues by the --stats dimensions
+	if layout.stats != nil {
+		if err := jira.NewIssueStats(issueList, layout.stats).Output(flags.Output); err != nil {
+			log.Fatalf("error printing statistics: %v", err)
+		}
+		return
+	}
+
+	// Run the bulk actions on the found issues
+	if len(flags.Do) > 0 {
+		if err := runBulkActions(client, issueList, flags); err != nil {
+			log.Fatalf("error running bulk actions: %v", err)
+		}
+		return
+	}
+
 	// Print the issues to the console
-	issueList.Print()
+	layout.apply(issueList)
+	if err := issueList.Output(flags.Output); err != nil {
+		log.Fatalf("error printing issues: %v", err)
+	}
+}
+
+// applyStatusStyles sets the colors of the status categories of the configuration.
+func applyStatusStyles(cfg *config.Config) error {
+	for category, style := range cfg.StatusStyles {
+		if err := theme.SetStatusStyle(category, style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newClient initializes the Jira client for the deployment type and authentication of the configuration.
+func newClient(cfg *config.Config) (*jira.Client, error) {
+	if cfg.Auth == config.AuthOAuth {
+		// Save the rotated tokens after each refresh
+		save := func(token *oauth2.Token) error {
+			cfg.OAuth.Token = token
+			return config.SaveOAuth(cfg)
+		}
+		return jira.NewOAuthClient(oauthAPIURL(cfg), cfg.OAuth.CloudID, oauthConfig(cfg, ""), cfg.OAuth.Token, save)
+	}
+	if cfg.IsServer() {
+		return jira.NewServerClient(cfg.JiraBaseURL, cfg.JiraAPIToken)
+	}
+	return jira.NewClient(cfg.JiraBaseURL, cfg.JiraAPIToken, cfg.JiraUserEmail)
 }
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// presetParam matches a parameter of a preset, such as {{project}}.
+var presetParam = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// presetName matches the valid names of a preset.
+var presetName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// applyPreset replaces the flags with the ones of the preset given with --preset or as
+// an @NAME search term. Arguments given in the command line are applied after the preset
+// ones, so they can override them.
+func applyPreset(cfg *config.Config, flags *config.Flags, searchTerms []string) (*config.Flags, []string, error) {
+	name := flags.Preset
+	args := flags.RawArgs
+	for i, term := range searchTerms {
+		if strings.HasPrefix(term, "@") && name == "" {
+			name = term[1:]
+			searchTerms = append(searchTerms[:i:i], searchTerms[i+1:]...)
+			args = removeArg(args, term)
+			break
+		}
+	}
+	if name == "" {
+		return flags, searchTerms, nil
+	}
+
+	preset, ok := cfg.Presets[strings.ToLower(name)]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown preset %q, available presets: %s", name, strings.Join(presetNames(cfg), ", "))
+	}
+	params, err := presetParams(cfg, flags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Raw JQL presets replace the query, quoting the parameter values
+	if preset.JQL != "" {
+		flags.Query, err = expandPreset(preset.JQL, params, func(name, value string) string {
+			if name == "user" && value == "me" {
+				return "currentUser()"
+			}
+			return jira.QuoteJQL(value)
+		})
+		return flags, searchTerms, err
+	}
+
+	// Argument presets are parsed again followed by the command line arguments
+	var presetArgs []string
+	for _, arg := range preset.Args {
+		arg, err := expandPreset(arg, params, func(name, value string) string { return value })
+		if err != nil {
+			return nil, nil, err
+		}
+		presetArgs = append(presetArgs, arg)
+	}
+	return config.ParseArgs(append(presetArgs, args...))
+}
+
+// presetParams returns the preset parameters given with --param, with {{user}} and
+// {{project}} defaulting to the --user and --project flags, and then to "me", the current
+// user in the assignee filter, and the default project of the profile.
+func presetParams(cfg *config.Config, flags *config.Flags) (map[string]string, error) {
+	params := map[string]string{"user": "me"}
+	if flags.Username != "" {
+		params["user"] = flags.Username
+	}
+	if cfg.Project != "" {
+		params["project"] = cfg.Project
+	}
+	if flags.Project != "" {
+		params["project"] = flags.Project
+	}
+
+	for _, param := range flags.Params {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid parameter %q, expected NAME=VALUE", param)
+		}
+		params[strings.TrimSpace(name)] = value
+	}
+	return params, nil
+}
+
+// expandPreset replaces the parameters of text with their values, formatted with the given function.
+func expandPreset(text string, params map[string]string, format func(name, value string) string) (string, error) {
+	var missing []string
+	expanded := presetParam.ReplaceAllStringFunc(text, func(match string) string {
+		name := presetParam.FindStringSubmatch(match)[1]
+		value, ok := params[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return format(name, value)
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing value for preset parameter %s, use --param %s=VALUE", missing[0], missing[0])
+	}
+	return expanded, nil
+}
+
+// removeArg returns args without the first occurrence of arg.
+func removeArg(args []string, arg string) []string {
+	for i, a := range args {
+		if a == arg {
+			return append(args[:i:i], args[i+1:]...)
+		}
+	}
+	return args
+}
+
+// presetNames returns the sorted names of the saved presets.
+func presetNames(cfg *config.Config) []string {
+	var names []string
+	for name := range cfg.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// listPresets prints the saved presets.
+func listPresets(cfg *config.Config) error {
+	if len(cfg.Presets) == 0 {
+		fmt.Println("No presets found.")
+		return nil
+	}
+
+	// Determine the maximum width for preset names
+	nameWidth := 0
+	for name := range cfg.Presets {
+		nameWidth = max(nameWidth, len(name)+1)
+	}
+
+	for _, name := range presetNames(cfg) {
+		preset := cfg.Presets[name]
+		definition := strings.Join(quoteArgs(preset.Args), " ")
+		if preset.JQL != "" {
+			definition = preset.JQL
+		}
+		fmt.Printf("%s %s\n", theme.Accent.Paintf("%-*s", nameWidth, "@"+name), definition)
+		if preset.Description != "" {
+			fmt.Printf("%-*s %s\n", nameWidth, "", theme.Secondary.Paint(preset.Description))
+		}
+	}
+	return nil
+}
+
+// addPreset saves a new preset or replaces an existing one.
+func addPreset(cfg *config.Config, opts config.PresetsAddCommand) error {
+	name := strings.ToLower(strings.TrimPrefix(opts.Args.Name, "@"))
+	if !presetName.MatchString(name) {
+		return fmt.Errorf("invalid preset name %q, use only letters, numbers, - and _", opts.Args.Name)
+	}
+	if opts.JQL == "" && len(opts.Args.Args) == 0 {
+		return fmt.Errorf("a preset needs --jql or query arguments after --")
+	}
+	if opts.JQL != "" && len(opts.Args.Args) > 0 {
+		return fmt.Errorf("a preset cannot have both --jql and query arguments")
+	}
+
+	_, exists := cfg.Presets[name]
+	cfg.Presets[name] = config.Preset{Description: opts.Description, Args: opts.Args.Args, JQL: opts.JQL}
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+
+	if exists {
+		fmt.Printf("Updated preset %s\n", theme.Accent.Paint("@"+name))
+	} else {
+		fmt.Printf("Added preset %s\n", theme.Accent.Paint("@"+name))
+	}
+	return nil
+}
+
+// removePreset deletes a saved preset.
+func removePreset(cfg *config.Config, opts config.PresetsRemoveCommand) error {
+	name := strings.ToLower(strings.TrimPrefix(opts.Args.Name, "@"))
+	if _, ok := cfg.Presets[name]; !ok {
+		return fmt.Errorf("unknown preset %q", opts.Args.Name)
+	}
+
+	delete(cfg.Presets, name)
+	if err := config.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+
+	fmt.Printf("Removed preset %s\n", theme.Accent.Paint("@"+name))
+	return nil
+}
+
+// quoteArgs quotes the arguments containing spaces for display.
+func quoteArgs(args []string) []string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t'\"") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return quoted
+}
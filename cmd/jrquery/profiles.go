@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// profileTestTimeout limits the time spent testing the credentials of each profile.
+const profileTestTimeout = 10 * time.Second
+
+// listProfiles prints the configured profiles, marking the active one, and tests their credentials.
+func listProfiles(cfg *config.Config) error {
+	names, err := config.ProfileNames()
+	if err != nil {
+		return err
+	}
+
+	// Determine the maximum width for profile names and URLs
+	profiles := make([]*config.Config, len(names))
+	errs := make([]error, len(names))
+	var nameWidth, urlWidth int
+	for i, name := range names {
+		profiles[i], errs[i] = config.LoadConfig(name)
+		nameWidth = max(nameWidth, len(name))
+		if profiles[i] != nil {
+			urlWidth = max(urlWidth, len(profiles[i].JiraBaseURL))
+		}
+	}
+
+	for i, name := range names {
+		marker := " "
+		if name == cfg.Profile {
+			marker = "*"
+		}
+		fmt.Printf("%s %s ", marker, theme.Accent.Paintf("%-*s", nameWidth, name))
+
+		profile := profiles[i]
+		if errs[i] != nil {
+			fmt.Println(theme.Failure.Paintf("✘ %v", errs[i]))
+			continue
+		}
+		fmt.Printf("%-*s ", urlWidth, profile.JiraBaseURL)
+
+		user, err := testProfile(profile)
+		if err != nil {
+			fmt.Println(theme.Failure.Paintf("✘ %v", err))
+			continue
+		}
+		if profile.JiraUserEmail == "" {
+			fmt.Printf("%s %s\n", theme.Success.Paint("✔"), user)
+			continue
+		}
+		fmt.Printf("%s %s <%s>\n", theme.Success.Paint("✔"), user, profile.JiraUserEmail)
+	}
+	return nil
+}
+
+// testProfile checks the credentials of a profile, returning the name of the authenticated user.
+func testProfile(profile *config.Config) (string, error) {
+	client, err := newClient(profile)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), profileTestTimeout)
+	defer cancel()
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	return user.DisplayName, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"irontec.com/jrquery/internal/theme"
+)
+
+// stdinReader is shared by all the interactive prompts.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// prompt asks the user to enter a value for the given label.
+func prompt(label string) (string, error) {
+	if !isTerminal(os.Stdin) {
+		return "", fmt.Errorf("%s is required but stdin is not a terminal", label)
+	}
+
+	fmt.Printf("%s: ", label)
+	value, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", label, err)
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// promptChoice asks the user to pick one of the given options, by number or by name.
+func promptChoice(label string, options []string) (string, error) {
+	if !isTerminal(os.Stdin) {
+		return "", fmt.Errorf("%s is required but stdin is not a terminal", label)
+	}
+
+	fmt.Printf("%s:\n", label)
+	for i, option := range options {
+		fmt.Printf("  %s) %s\n", theme.Accent.Paint(strconv.Itoa(i+1)), option)
+	}
+
+	for {
+		value, err := prompt("Choose an option")
+		if err != nil {
+			return "", err
+		}
+		if index, err := strconv.Atoi(value); err == nil && index >= 1 && index <= len(options) {
+			return options[index-1], nil
+		}
+		for _, option := range options {
+			if strings.EqualFold(option, value) {
+				return option, nil
+			}
+		}
+		fmt.Println("Invalid option, try again.")
+	}
+}
+
+// confirm asks the user a yes/no question, returning false when stdin is not a terminal.
+func confirm(question string) bool {
+	if !isTerminal(os.Stdin) {
+		return false
+	}
+
+	fmt.Printf("%s [y/N]: ", question)
+	answer, _ := stdinReader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
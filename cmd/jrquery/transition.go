@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// transitionIssue moves an issue to a new status, prompting for any required screen fields.
+func transitionIssue(client *jira.Client, flags *config.Flags) error {
+	ctx := context.Background()
+	issueKey := flags.Transition.Args.Issue
+
+	transitions, err := client.GetTransitions(ctx, issueKey)
+	if err != nil {
+		return err
+	}
+
+	// Without a target status, list the available transitions
+	if flags.Transition.Args.Status == "" {
+		transitions.Print()
+		return nil
+	}
+
+	transition, err := transitions.Find(flags.Transition.Args.Status)
+	if err != nil {
+		return err
+	}
+
+	fields, comment, err := transitionFieldValues(transition, flags.Transition.Resolution, flags.Transition.Comment)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DoTransition(ctx, issueKey, transition, fields, comment); err != nil {
+		return err
+	}
+
+	// Report the resulting status
+	issue, err := client.GetIssue(ctx, issueKey)
+	if err != nil {
+		return err
+	}
+	status := transition.To.Name
+	if issue.Fields != nil && issue.Fields.Status != nil {
+		status = issue.Fields.Status.Name
+	}
+	fmt.Printf("%s is now %s\n", theme.Accent.Paint(issue.Key), theme.Success.Paint(status))
+	return nil
+}
+
+// transitionFieldValues collects the values of the transition screen fields, prompting for the
+// required ones that were not given in the command line.
+func transitionFieldValues(transition *jira.Transition, resolution, comment string) (map[string]any, string, error) {
+	fields := make(map[string]any)
+
+	if resolution != "" {
+		field, ok := transition.Fields["resolution"]
+		if !ok {
+			return nil, "", fmt.Errorf("transition %s does not allow setting a resolution", transition.Name)
+		}
+		value, err := field.Value(resolution)
+		if err != nil {
+			return nil, "", err
+		}
+		fields["resolution"] = value
+	}
+
+	for _, id := range transition.RequiredFields() {
+		if _, ok := fields[id]; ok {
+			continue
+		}
+		field := transition.Fields[id]
+
+		// Comments are added through the update section instead of the fields
+		if id == "comment" {
+			if comment == "" {
+				var err error
+				if comment, err = prompt(field.Name); err != nil {
+					return nil, "", err
+				}
+			}
+			continue
+		}
+
+		var input string
+		var err error
+		if len(field.AllowedValues) > 0 {
+			var options []string
+			for _, allowed := range field.AllowedValues {
+				options = append(options, allowed.Label())
+			}
+			input, err = promptChoice(field.Name, options)
+		} else {
+			input, err = prompt(field.Name)
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		value, err := field.Value(input)
+		if err != nil {
+			return nil, "", err
+		}
+		fields[id] = value
+	}
+
+	return fields, comment, nil
+}
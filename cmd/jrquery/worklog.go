@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"irontec.com/jrquery/config"
+	"irontec.com/jrquery/internal/jira"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// logWork adds a worklog to an issue.
+func logWork(client *jira.Client, flags *config.Flags) error {
+	opts := flags.Log
+	issueKey := opts.Args.Issue
+
+	seconds, err := jira.ParseWorkDuration(opts.Args.Duration)
+	if err != nil {
+		return err
+	}
+	started, err := jira.ParseDateTime(opts.Started, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.AddWorklog(context.Background(), issueKey, started, seconds, opts.Message); err != nil {
+		return err
+	}
+
+	fmt.Printf("Logged %s on %s (started %s)\n", theme.Number.Paint(jira.FormatWorkDuration(seconds)), theme.Accent.Paint(issueKey), started.Format("02-01-2006 15:04"))
+	return nil
+}
+
+// listWorklogs prints the worklogs and time tracking estimates of an issue.
+func listWorklogs(client *jira.Client, flags *config.Flags) error {
+	worklogs, err := client.GetWorklogs(context.Background(), flags.Worklogs.Args.Issue)
+	if err != nil {
+		return err
+	}
+
+	return worklogs.Output(flags.Output)
+}
+
+// showTimesheet prints the time logged by a user on every issue and day of a date range.
+func showTimesheet(client *jira.Client, flags *config.Flags) error {
+	ctx := context.Background()
+	opts := flags.Timesheet
+
+	from, to, err := timesheetRange(opts, time.Now())
+	if err != nil {
+		return err
+	}
+
+	user, err := resolveUser(ctx, client, opts.User)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("a user is required for the timesheet")
+	}
+
+	timesheet := jira.NewTimesheet(user.DisplayName, from, to)
+
+	// Find the issues with worklogs of the user in the range
+	jql := jira.NewQueryBuilder().
+		AddFilter("worklogAuthor", "=", client.UserID(user)).
+		AddFilter("worklogDate", ">=", timesheet.From.Format("2006-01-02")).
+		AddFilter("worklogDate", "<=", timesheet.To.Format("2006-01-02")).
+		Build()
+	if flags.Debug {
+		fmt.Fprintf(os.Stderr, "Searching issues for JQL: %s\n", jql)
+	}
+	// Fetch every issue, as a missing one would leave its time out of the totals
+	issues, err := client.SearchIssuesWithPagination(jql, math.MaxInt)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues.Issues {
+		worklogs, err := client.GetIssueWorklogs(ctx, issue.Key, timesheet.From.Add(-time.Millisecond))
+		if err != nil {
+			return err
+		}
+		timesheet.Add(issue, worklogs, client.UserID(user))
+	}
+
+	return timesheet.Output(flags.Output)
+}
+
+// timesheetRange returns the first and last day of the timesheet, the current week by default.
+func timesheetRange(opts config.TimesheetCommand, now time.Time) (time.Time, time.Time, error) {
+	if opts.Week || (opts.From == "" && opts.To == "") {
+		if opts.From != "" || opts.To != "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("--week cannot be combined with --from or --to")
+		}
+		from := jira.StartOfWeek(now)
+		return from, from.AddDate(0, 0, 6), nil
+	}
+
+	to := now
+	if opts.To != "" {
+		var err error
+		if to, err = jira.ParseDateTime(opts.To, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	// Without --from, show the week ending on the last day
+	from := to.AddDate(0, 0, -6)
+	if opts.From != "" {
+		var err error
+		if from, err = jira.ParseDateTime(opts.From, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the start of the range is after its end")
+	}
+	return from, to, nil
+}
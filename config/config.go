@@ -2,19 +2,94 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/spf13/viper"
+	"irontec.com/jrquery/internal/theme"
 )
 
+// DefaultProfile is the name of the profile stored at the top level of the config file.
+const DefaultProfile = "default"
+
+// Deployment types of a Jira instance, saved in the deployment key of each profile.
+const (
+	DeploymentCloud  = "cloud"
+	DeploymentServer = "server"
+)
+
+// ErrNotConfigured is returned by LoadConfig when the profile has no credentials.
+var ErrNotConfigured = errors.New("not configured")
+
+// profileName matches the valid names of a profile.
+var profileName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// fileConfig holds only the keys read from the config file, without the environment
+// variables and defaults of the global viper instance. Changes are made to it and
+// written back to the file, so those values are never saved.
+var fileConfig = viper.New()
+
 // Config struct holds the application configuration
 type Config struct {
+	Profile       string
+	Deployment    string
+	Auth          string
+	OAuth         OAuthConfig
 	JiraBaseURL   string
 	JiraAPIToken  string
 	JiraUserEmail string
+	Project       string
+	Presets       map[string]Preset
+	Columns       map[string]string
+	StatusStyles  map[string]theme.Style
+
+	// saveToken is set when the API token was prompted and must be saved
+	saveToken bool
+	// saveOAuth is set when the OAuth authorization changed and must be saved
+	saveOAuth bool
+}
+
+// Preset is a saved query, defined either as command line arguments or as raw JQL.
+// Both can contain parameters such as {{project}} or {{user}}.
+type Preset struct {
+	Description string   `mapstructure:"description" json:"description,omitempty"`
+	Args        []string `mapstructure:"args" json:"args,omitempty"`
+	JQL         string   `mapstructure:"jql" json:"jql,omitempty"`
+}
+
+// IsServer returns true if the profile connects to Jira Server or Data Center.
+func (c *Config) IsServer() bool {
+	return c.Deployment == DeploymentServer
+}
+
+// secretAccount returns the account of the API token in the secret stores.
+func (c *Config) secretAccount() string {
+	if c.JiraUserEmail == "" {
+		return c.JiraBaseURL
+	}
+	return c.JiraUserEmail
+}
+
+// parseDeployment returns the deployment type of the given name, cloud by default.
+func parseDeployment(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", DeploymentCloud:
+		return DeploymentCloud, nil
+	case DeploymentServer, "datacenter", "data-center", "dc":
+		return DeploymentServer, nil
+	}
+	return "", fmt.Errorf("invalid deployment type %q, use cloud or server", name)
+}
+
+// BrowseURL returns the web URL of the given issue key.
+func (c *Config) BrowseURL(issueKey string) string {
+	return fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(c.JiraBaseURL, "/"), issueKey)
 }
 
 func getUserConfigPath() (string, error) {
@@ -25,81 +100,364 @@ func getUserConfigPath() (string, error) {
 	return filepath.Join(homeDir, ".config", "jrquery.json"), nil
 }
 
-// LoadConfig loads configuration from environment variables or a config file.
-func LoadConfig() (*Config, error) {
-
+// readConfigFile configures Viper and reads the configuration file.
+func readConfigFile() error {
 	// Get the configuration file path
 	configPath, err := getUserConfigPath()
 	if err != nil {
-		fmt.Println("Error getting configuration file path:", err)
-		return nil, err
+		return err
 	}
 
 	// Configure Viper
 	viper.SetConfigFile(configPath)
 	viper.SetConfigType("json")
 
-	// Set default values
-	viper.SetDefault("jira.base_url", "")
-	viper.SetDefault("jira.api_token", "")
-	viper.SetDefault("jira.user_email", "")
-
 	// Enable reading from environment variables
 	viper.AutomaticEnv()
 
-	// Map environment variables to viper keys
-	viper.BindEnv("jira.base_url", "JIRA_BASE_URL")
-	viper.BindEnv("jira.api_token", "JIRA_API_TOKEN")
-	viper.BindEnv("jira.user_email", "JIRA_USER_EMAIL")
+	// Keep a copy of the file alone to save the changes
+	fileConfig = viper.New()
+	fileConfig.SetConfigFile(configPath)
+	fileConfig.SetConfigType("json")
+	if err := fileConfig.ReadInConfig(); err != nil {
+		return err
+	}
 
 	// Attempt to read from config file, if exists
-	if err := viper.ReadInConfig(); err != nil {
+	return viper.ReadInConfig()
+}
+
+// resolveProfile returns the profile to use: the given one, the default_profile of the
+// config file or DefaultProfile.
+func resolveProfile(profile string) (string, error) {
+	if profile == "" {
+		profile = viper.GetString("default_profile")
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	// Viper keys are case insensitive
+	profile = strings.ToLower(profile)
+	if !profileName.MatchString(profile) {
+		return "", fmt.Errorf("invalid profile name %q, use only letters, numbers, - and _", profile)
+	}
+	return profile, nil
+}
+
+// profileKey returns the prefix of the configuration keys of a profile.
+func profileKey(profile string) string {
+	if profile == DefaultProfile {
+		return ""
+	}
+	return "profiles." + profile + "."
+}
+
+// LoadConfig loads the configuration of a profile from environment variables or a config file.
+// An empty profile selects the default_profile of the config file or DefaultProfile.
+func LoadConfig(profile string) (*Config, error) {
+	// A missing config file is not an error, the environment may have the credentials
+	if err := readConfigFile(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	profile, err := resolveProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	prefix := profileKey(profile)
+
+	// Set default values
+	viper.SetDefault(prefix+"jira.base_url", "")
+	viper.SetDefault(prefix+"jira.api_token", "")
+	viper.SetDefault(prefix+"jira.user_email", "")
+
+	// Map environment variables to viper keys of the profile
+	for key, env := range envSettings {
+		viper.BindEnv(prefix+key, env)
+	}
+
+	deployment, err := parseDeployment(viper.GetString(prefix + "jira.deployment"))
+	if err != nil {
+		return nil, err
+	}
+	auth, err := parseAuth(viper.GetString(prefix + "jira.auth"))
+	if err != nil {
 		return nil, err
 	}
 
 	// Return the configuration
 	config := &Config{
-		JiraBaseURL:   viper.GetString("jira.base_url"),
-		JiraAPIToken:  viper.GetString("jira.api_token"),
-		JiraUserEmail: viper.GetString("jira.user_email"),
+		Profile:       profile,
+		Deployment:    deployment,
+		Auth:          auth,
+		JiraBaseURL:   viper.GetString(prefix + "jira.base_url"),
+		JiraAPIToken:  viper.GetString(prefix + "jira.api_token"),
+		JiraUserEmail: viper.GetString(prefix + "jira.user_email"),
+		Project:       viper.GetString(prefix + "project"),
+	}
+
+	// OAuth replaces the API token with the refreshable tokens of the authorization
+	if config.Auth == AuthOAuth {
+		if config.IsServer() {
+			return nil, fmt.Errorf("OAuth authorization of profile %q is only supported on Jira Cloud", profile)
+		}
+		if config.OAuth, err = loadOAuth(profile); err != nil {
+			return nil, fmt.Errorf("error reading OAuth authorization of profile %q: %w", profile, err)
+		}
+		if config.JiraBaseURL == "" || config.OAuth.CloudID == "" || config.OAuth.Token == nil {
+			return nil, fmt.Errorf("profile %q is %w, run 'jrquery login --oauth'", profile, ErrNotConfigured)
+		}
+		if err := loadPresets(config); err != nil {
+			return nil, err
+		}
+		if err := loadColors(config); err != nil {
+			return nil, err
+		}
+		return config, nil
+	}
+
+	// Tokens not kept in plain text are read from the token command or the secret store
+	if config.JiraAPIToken == "" && config.JiraBaseURL != "" {
+		token, err := loadToken(profile, config.secretAccount())
+		if err != nil {
+			return nil, fmt.Errorf("error reading API token of profile %q: %w", profile, err)
+		}
+		config.JiraAPIToken = token
+	}
+	// Server and Data Center authenticate with a Personal Access Token, without email
+	if config.JiraBaseURL == "" || config.JiraAPIToken == "" || (config.JiraUserEmail == "" && !config.IsServer()) {
+		return nil, fmt.Errorf("profile %q is %w", profile, ErrNotConfigured)
 	}
 
+	if err := loadPresets(config); err != nil {
+		return nil, err
+	}
+	if err := loadColors(config); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
-// SaveConfig saves the current configuration to a file.
+// loadColors loads the styles of the status categories, shared by all the profiles.
+func loadColors(config *Config) error {
+	config.StatusStyles = make(map[string]theme.Style)
+	for category, value := range viper.GetStringMapString("colors.status") {
+		if !slices.Contains(theme.StatusCategories, category) {
+			return fmt.Errorf("unknown status category %q in colors, use %s", category, strings.Join(theme.StatusCategories, ", "))
+		}
+		style, err := theme.ParseStyle(value)
+		if err != nil {
+			return fmt.Errorf("error reading color of status category %q: %w", category, err)
+		}
+		config.StatusStyles[category] = style
+	}
+	return nil
+}
+
+// loadPresets loads the saved query and column presets of the profile of a configuration.
+func loadPresets(config *Config) error {
+	if err := viper.UnmarshalKey(profileKey(config.Profile)+"presets", &config.Presets); err != nil {
+		return fmt.Errorf("error reading presets: %w", err)
+	}
+	if config.Presets == nil {
+		config.Presets = make(map[string]Preset)
+	}
+	config.Columns = viper.GetStringMapString(profileKey(config.Profile) + "columns")
+	return nil
+}
+
+// loadToken returns the API token of a profile from its token_command or its token_store,
+// or an empty token if it has none.
+func loadToken(profile, account string) (string, error) {
+	if command := viper.GetString(profileKey(profile) + "jira.token_command"); command != "" {
+		return runTokenCommand(command)
+	}
+	return loadSecret(profile, account, "jira.api_token")
+}
+
+// saveToken saves the API token of a profile in its secret store, keeping it in the
+// config file only with the plain store.
+func saveToken(cfg *Config) error {
+	return saveSecret(cfg.Profile, cfg.secretAccount(), "jira.api_token", cfg.JiraAPIToken)
+}
+
+// profileSecretStore returns the name of the secret store of a profile from its token_store
+// key, empty if its secrets were never saved.
+func profileSecretStore(profile string) string {
+	return fileConfig.GetString(profileKey(profile) + "jira.token_store")
+}
+
+// loadSecret returns a secret of a profile from the store of its token_store key, or from
+// the given key of the profile with the plain store. It returns an empty secret if not found.
+func loadSecret(profile, account, plainKey string) (string, error) {
+	prefix := profileKey(profile)
+	name := profileSecretStore(profile)
+	if name == "" || name == SecretStorePlain {
+		return viper.GetString(prefix + plainKey), nil
+	}
+
+	store, err := NewSecretStore(name)
+	if err != nil {
+		return "", err
+	}
+	secret, err := store.Get(profile, account)
+	if errors.Is(err, errSecretNotFound) {
+		return "", nil
+	}
+	return secret, err
+}
+
+// saveSecret saves a secret of a profile in the store of its token_store key, the same one
+// loadSecret reads, or in the given key of the profile with the plain store. Profiles without
+// a store yet use the one selected by the secret_store key.
+func saveSecret(profile, account, plainKey, secret string) error {
+	prefix := profileKey(profile)
+	store, err := NewSecretStore(saveSecretStore(profile))
+	if err != nil {
+		return err
+	}
+
+	if store == nil {
+		fileConfig.Set(prefix+plainKey, secret)
+		fileConfig.Set(prefix+"jira.token_store", SecretStorePlain)
+		return nil
+	}
+	if err := store.Set(profile, account, secret); err != nil {
+		return err
+	}
+	if fileConfig.GetString(prefix+plainKey) != "" {
+		fileConfig.Set(prefix+plainKey, "")
+	}
+	fileConfig.Set(prefix+"jira.token_store", store.Name())
+	return nil
+}
+
+// saveSecretStore returns the name of the store saveSecret uses for a profile: its token_store
+// key or, if its secrets were never saved, the secret_store key.
+func saveSecretStore(profile string) string {
+	if name := profileSecretStore(profile); name != "" {
+		return name
+	}
+	return fileConfig.GetString("secret_store")
+}
+
+// ProfileNames returns the sorted names of the profiles in the config file.
+func ProfileNames() ([]string, error) {
+	if err := readConfigFile(); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if viper.IsSet("jira.base_url") {
+		names = append(names, DefaultProfile)
+	}
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SaveConfig saves the current configuration to a file, in the keys of its profile. Only the
+// values changed since the configuration was loaded are written, so the ones given by
+// environment variables are never saved.
 func SaveConfig(cfg *Config) error {
-	viper.Set("jira.base_url", cfg.JiraBaseURL)
-	viper.Set("jira.api_token", cfg.JiraAPIToken)
-	viper.Set("jira.user_email", cfg.JiraUserEmail)
+	prefix := profileKey(cfg.Profile)
+	setChanged(prefix+"jira.base_url", cfg.JiraBaseURL)
+	setChanged(prefix+"jira.user_email", cfg.JiraUserEmail)
+	if cfg.IsServer() || fileConfig.IsSet(prefix+"jira.deployment") {
+		setChanged(prefix+"jira.deployment", cfg.Deployment)
+	}
+	if cfg.Auth != "" && (cfg.Auth != AuthToken || fileConfig.IsSet(prefix+"jira.auth")) {
+		setChanged(prefix+"jira.auth", cfg.Auth)
+	}
+	if cfg.saveToken {
+		if err := saveToken(cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.saveOAuth {
+		if err := saveOAuth(cfg); err != nil {
+			return err
+		}
+	}
+	if cfg.Project != "" {
+		setChanged(prefix+"project", cfg.Project)
+	}
+	if cfg.Presets != nil {
+		fileConfig.Set(prefix+"presets", cfg.Presets)
+	}
+	return writeConfigFile()
+}
 
+// setChanged sets a key of the config file if the value differs from the loaded one, which
+// may come from the file, an environment variable or a default.
+func setChanged(key, value string) {
+	if value != viper.GetString(key) {
+		fileConfig.Set(key, value)
+	}
+}
+
+// writeConfigFile writes the keys read from the config file, with their changes, to the file.
+func writeConfigFile() error {
 	// Get the configuration file path
 	configPath, err := getUserConfigPath()
 	if err != nil {
-		fmt.Println("Error getting configuration file path:", err)
 		return err
 	}
 
 	// Ensure the directory exists
 	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, os.ModePerm); err != nil {
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
 		return fmt.Errorf("could not create configuration directory: %v", err)
 	}
 
-	// Write the configuration to the file
-	return viper.WriteConfig()
+	// Write the configuration to the file, only readable by the user as it may hold tokens
+	fileConfig.SetConfigFile(configPath)
+	fileConfig.SetConfigType("json")
+	fileConfig.SetConfigPermissions(0o600)
+	if err := fileConfig.WriteConfig(); err != nil {
+		return err
+	}
+	return os.Chmod(configPath, 0o600)
 }
 
-// CheckAndPromptConfig checks if configuration is present; if not, prompts user for input and saves it.
-func PromptConfig() error {
+// PromptConfig prompts the user for the credentials of a profile and adds it to the config file,
+// keeping the other profiles. An empty profile selects the same one as LoadConfig.
+func PromptConfig(profile string) error {
+
+	// Keep the existing configuration, if any
+	readConfigFile()
+	profile, err := resolveProfile(profile)
+	if err != nil {
+		return err
+	}
 
-	config := &Config{}
+	config := &Config{Profile: profile, Auth: AuthToken}
 	reader := bufio.NewReader(os.Stdin)
 
+	// Check the secret store before prompting, offering to keep the token in the config
+	// file if it is not available
+	if _, err := NewSecretStore(saveSecretStore(profile)); err != nil {
+		fmt.Println(err)
+		fmt.Print("Keep the API token in the config file instead? [y/N]: ")
+		answer, _ := reader.ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return err
+		}
+		fileConfig.Set(profileKey(profile)+"jira.token_store", SecretStorePlain)
+	}
+
 	fmt.Println("You need a Jira API token to use jrquery.")
 	fmt.Println()
-	fmt.Println("\033[1;37mhttps://id.atlassian.com/manage-profile/security/api-tokens\033[0m")
+	fmt.Println(theme.Title.Paint("https://id.atlassian.com/manage-profile/security/api-tokens"))
+	fmt.Println()
+	fmt.Println("If your organization disables API tokens, run 'jrquery login --oauth' instead.")
 	fmt.Println()
+	if profile != DefaultProfile {
+		fmt.Printf("Configuring profile %s\n", theme.Accent.Paint(profile))
+	}
 
 	// Prompt for Jira BaseURL
 	if config.JiraBaseURL == "" {
@@ -108,20 +466,43 @@ func PromptConfig() error {
 		config.JiraBaseURL = strings.TrimSpace(url)
 	}
 
-	// Prompt for the Username
-	if config.JiraUserEmail == "" {
+	// Prompt for the deployment type, Cloud unless said otherwise
+	fmt.Print("Enter the deployment type, cloud or server (Server/Data Center) [cloud]: ")
+	deployment, _ := reader.ReadString('\n')
+	if config.Deployment, err = parseDeployment(deployment); err != nil {
+		return err
+	}
+
+	// Prompt for the Username, not needed with the Personal Access Tokens of Server
+	if config.JiraUserEmail == "" && !config.IsServer() {
 		fmt.Print("Enter your email: ")
 		email, _ := reader.ReadString('\n')
 		config.JiraUserEmail = strings.TrimSpace(email)
 	}
 
-	// Prompt for the APIToken
+	// Prompt for the APIToken, or the Personal Access Token of Server
 	if config.JiraAPIToken == "" {
-		fmt.Print("Enter your APIToken: ")
+		if config.IsServer() {
+			fmt.Print("Enter your Personal Access Token: ")
+		} else {
+			fmt.Print("Enter your APIToken: ")
+		}
 		token, _ := reader.ReadString('\n')
 		config.JiraAPIToken = strings.TrimSpace(token)
+		config.saveToken = true
 	}
 
+	// Prompt for the optional default project
+	fmt.Print("Enter the default project key (optional): ")
+	project, _ := reader.ReadString('\n')
+	config.Project = strings.TrimSpace(project)
+
 	// Save the new config if any of the values were prompted
-	return SaveConfig(config)
+	if err := SaveConfig(config); err != nil {
+		return err
+	}
+	if store := profileSecretStore(profile); store != SecretStorePlain {
+		fmt.Printf("API token saved in the %s store\n", store)
+	}
+	return nil
 }
@@ -1,43 +1,290 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jessevdk/go-flags"
 )
 
 // Flags struct holds the command-line flags for the application
 type Flags struct {
-	Debug        bool   `short:"d" long:"debug" description:"Print debugging information"`
-	Username     string `short:"u" long:"user" description:"Name or email of assigned user"`
-	Project      string `short:"p" long:"project" description:"Key of project to search issues"`
-	Search       []bool `short:"s" long:"search" description:"Search text in summary, issue description or comments"`
-	Limit        int    `short:"l" long:"limit" default:"50" description:"Limit output to first N results"`
-	Count        bool   `short:"c" long:"count" description:"Only print issue count"`
-	Sprint       bool   `short:"S" long:"sprint" description:"Only print issues with active sprint"`
-	Status       string `short:"e" long:"status" description:"Only print issues with given status Name"`
-	Unresolved   bool   `short:"O" long:"unresolved" description:"Only print unresolved issues"`
-	All          bool   `short:"A" long:"all" description:"Print all issues no matter their status"`
-	Query        string `short:"q" long:"query" description:"Run a custom query"`
-	Filter       string `short:"f" long:"filter" description:"Search issues using a saved Jira filter ID"`
-	Open         string `short:"o" long:"open" description:"Open given issue in a browser tab"`
-	OrderByTime  []bool `short:"T" long:"order-by-time" description:"Sort issues by last updated time (use -TT for reverse)"`
-	OrderByUser  []bool `short:"U" long:"order-by-user" description:"Sort issues by assignee (use -UU for reverse ordering)"`
-	ListProjects bool   `long:"list-projects" description:"List all visible projects for current user"`
-	ListUsers    bool   `long:"list-users" description:"List all users in Jira"`
-	ListFilters  bool   `long:"list-filters" description:"List all saved filters in Jira"`
-	PrintFilter  int    `long:"print-filter" description:"Print the JQL query of a Jira filter by ID"`
-	Version      bool   `short:"v" long:"version" description:"Show the version"`
+	Debug         bool     `short:"d" long:"debug" description:"Print debugging information"`
+	Username      string   `short:"u" long:"user" description:"Name or email of assigned user, or 'me'"`
+	Project       string   `short:"p" long:"project" description:"Key of project to search issues"`
+	Search        []bool   `short:"s" long:"search" description:"Search text in summary, issue description or comments"`
+	Limit         int      `short:"l" long:"limit" default:"50" description:"Limit output to first N results"`
+	Count         bool     `short:"c" long:"count" description:"Only print issue count"`
+	Stats         bool     `long:"stats" description:"Only print issue counts by status category, assignee, type, priority and age"`
+	StatsBy       []string `long:"stats-by" description:"Count issues by given fields instead, e.g. category, age, sprint or labels (can be repeated or comma separated)"`
+	Sprint        bool     `short:"S" long:"sprint" description:"Only print issues with active sprint"`
+	Status        string   `short:"e" long:"status" description:"Only print issues with given status Name"`
+	Unresolved    bool     `short:"O" long:"unresolved" description:"Only print unresolved issues"`
+	Type          []string `short:"t" long:"type" description:"Only print issues of given type (can be repeated or comma separated)"`
+	Priority      []string `short:"P" long:"priority" description:"Only print issues with given priority (can be repeated or comma separated)"`
+	Label         []string `short:"L" long:"label" description:"Only print issues with given label (can be repeated or comma separated)"`
+	Component     []string `short:"C" long:"component" description:"Only print issues in given component (can be repeated or comma separated)"`
+	Reporter      []string `long:"reporter" description:"Only print issues reported by given user or 'me' (can be repeated or comma separated)"`
+	FixVersion    []string `long:"fix-version" description:"Only print issues with given fix version (can be repeated or comma separated)"`
+	Epic          []string `long:"epic" description:"Only print issues in given epic or parent issue (can be repeated or comma separated)"`
+	CreatedSince  string   `long:"created-since" description:"Only print issues created since given date, e.g. 3d, yesterday, 'last monday' or 2024-05-01"`
+	UpdatedSince  string   `long:"updated-since" description:"Only print issues updated since given date"`
+	ResolvedSince string   `long:"resolved-since" description:"Only print issues resolved since given date"`
+	DueBefore     string   `long:"due-before" description:"Only print issues due before given date, e.g. 2w, 'next friday' or 2024-05-31"`
+	All           bool     `short:"A" long:"all" description:"Print all issues no matter their status"`
+	Query         string   `short:"q" long:"query" description:"Run a custom query"`
+	Filter        string   `short:"f" long:"filter" description:"Search issues using a saved Jira filter ID"`
+	Open          string   `short:"o" long:"open" description:"Open given issue in a browser tab"`
+	OrderByTime   []bool   `short:"T" long:"order-by-time" description:"Sort issues by last updated time (use -TT for reverse)"`
+	OrderByUser   []bool   `short:"U" long:"order-by-user" description:"Sort issues by assignee (use -UU for reverse ordering)"`
+	ListProjects  bool     `long:"list-projects" description:"List all visible projects for current user"`
+	ListUsers     bool     `long:"list-users" description:"List all users in Jira"`
+	ListFilters   bool     `long:"list-filters" description:"List all saved filters in Jira"`
+	PrintFilter   int      `long:"print-filter" description:"Print the JQL query of a Jira filter by ID"`
+	Preset        string   `long:"preset" description:"Run a query preset saved in the configuration (same as @NAME)"`
+	Params        []string `long:"param" description:"Value of a preset parameter as NAME=VALUE (can be repeated)"`
+	Do            []string `long:"do" description:"Run an action on the matching issues: transition:STATUS, assign:USER, label:+NAME/-NAME or comment:TEXT"`
+	Yes           bool     `short:"y" long:"yes" description:"Run bulk actions without asking for confirmation"`
+	DryRun        bool     `long:"dry-run" description:"Only preview the issues affected by bulk actions"`
+	Concurrency   int      `long:"concurrency" default:"4" description:"Number of issues updated in parallel by bulk actions"`
+	Output        string   `long:"output" default:"text" choice:"text" choice:"json" choice:"ndjson" choice:"csv" choice:"tsv" description:"Output format for listings"`
+	Columns       string   `long:"columns" description:"Columns of the issue listings, e.g. key,priority,sprint,summary, or the name of a column preset"`
+	Format        string   `long:"format" description:"Go template to print each issue, e.g. '{{.Key}} {{.Fields.Summary}}'"`
+	GroupBy       string   `long:"group-by" choice:"assignee" choice:"status" choice:"project" choice:"epic" choice:"sprint" choice:"priority" description:"Print the issues in sections by the value of a field"`
+	Wide          bool     `short:"W" long:"wide" description:"Do not shorten the listings to the width of the terminal"`
+	Color         string   `long:"color" default:"auto" choice:"auto" choice:"always" choice:"never" description:"Colorize the output (auto disables it without a terminal or with NO_COLOR)"`
+	Profile       string   `long:"profile" env:"JIRA_PROFILE" description:"Name of the Jira instance profile to use"`
+	Version       bool     `short:"v" long:"version" description:"Show the version"`
+
+	// Subcommands
+	Show       ShowCommand       `command:"show" description:"Show the details of an issue"`
+	Comments   CommentsCommand   `command:"comments" description:"List the comments of an issue"`
+	Comment    CommentCommand    `command:"comment" description:"Add a comment to an issue"`
+	Transition TransitionCommand `command:"transition" description:"Move an issue to a new status"`
+	Assign     AssignCommand     `command:"assign" description:"Assign an issue to a user"`
+	Create     CreateCommand     `command:"create" description:"Create a new issue"`
+	Edit       EditCommand       `command:"edit" description:"Edit the fields of an issue"`
+	Log        LogCommand        `command:"log" description:"Log work time on an issue"`
+	Worklogs   WorklogsCommand   `command:"worklogs" description:"List the worklogs and time tracking of an issue"`
+	Timesheet  TimesheetCommand  `command:"timesheet" description:"Show the time logged by a user per issue and day"`
+	Presets    PresetsCommand    `command:"presets" description:"Manage the saved query presets"`
+	Profiles   ProfilesCommand   `command:"profiles" description:"List the Jira instance profiles and test their credentials"`
+	Login      LoginCommand      `command:"login" description:"Configure the credentials of the profile"`
+	Config     ConfigCommand     `command:"config" description:"Show, change and validate the configuration of the profile"`
+
+	// Name of the subcommand given in the command line, if any
+	Command string
+	// Arguments the flags were parsed from
+	RawArgs []string
+}
+
+// ShowCommand holds the arguments of the show subcommand
+type ShowCommand struct {
+	Args struct {
+		Issue string `positional-arg-name:"ISSUE" description:"Key of the issue to show"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// CommentsCommand holds the arguments of the comments subcommand
+type CommentsCommand struct {
+	Args struct {
+		Issue string `positional-arg-name:"ISSUE" description:"Key of the issue"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// CommentCommand holds the arguments of the comment subcommand
+type CommentCommand struct {
+	Message string `short:"m" long:"message" description:"Comment text (opens $EDITOR when not given)"`
+	Args    struct {
+		Issue string `positional-arg-name:"ISSUE" description:"Key of the issue"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// TransitionCommand holds the arguments of the transition subcommand
+type TransitionCommand struct {
+	Comment    string `short:"m" long:"comment" description:"Comment to add while transitioning"`
+	Resolution string `short:"r" long:"resolution" description:"Resolution to set while transitioning"`
+	Args       struct {
+		Issue  string `positional-arg-name:"ISSUE" required:"yes" description:"Key of the issue"`
+		Status string `positional-arg-name:"STATUS" description:"Transition or target status name (lists them when omitted)"`
+	} `positional-args:"yes"`
+}
+
+// AssignCommand holds the arguments of the assign subcommand
+type AssignCommand struct {
+	Args struct {
+		Issue string `positional-arg-name:"ISSUE" description:"Key of the issue"`
+		User  string `positional-arg-name:"USER" description:"Name, email, 'me' or 'none' to unassign"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// CreateCommand holds the arguments of the create subcommand
+type CreateCommand struct {
+	Project     string   `short:"p" long:"project" description:"Key of the project (prompted when not given)"`
+	Type        string   `short:"t" long:"type" description:"Name of the issue type (prompted when not given)"`
+	Summary     string   `short:"s" long:"summary" description:"Summary of the issue (prompted when not given)"`
+	Description string   `short:"m" long:"description" description:"Description of the issue (read from stdin when piped)"`
+	Edit        bool     `short:"E" long:"edit" description:"Write the description in $EDITOR"`
+	Labels      []string `short:"L" long:"label" description:"Label to add (can be repeated)"`
+	Components  []string `short:"C" long:"component" description:"Component to add (can be repeated)"`
+	Priority    string   `short:"P" long:"priority" description:"Name of the priority"`
+	Assignee    string   `short:"a" long:"assignee" description:"Name, email or 'me' of the assignee"`
+	Parent      string   `long:"parent" description:"Key of the parent issue or epic"`
+	Fields      []string `short:"F" long:"field" description:"Other field value as NAME=VALUE (can be repeated)"`
+}
+
+// EditCommand holds the arguments of the edit subcommand
+type EditCommand struct {
+	Set    []string `long:"set" description:"Replace a field value as NAME=VALUE (can be repeated)"`
+	Add    []string `long:"add" description:"Add values to a multi-value field as NAME=VALUE (can be repeated)"`
+	Remove []string `long:"remove" description:"Remove values from a multi-value field as NAME=VALUE (can be repeated)"`
+	DryRun bool     `short:"n" long:"dry-run" description:"Print the request payload without editing the issue"`
+	Args   struct {
+		Issue string `positional-arg-name:"ISSUE" description:"Key of the issue"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// LogCommand holds the arguments of the log subcommand
+type LogCommand struct {
+	Message string `short:"m" long:"message" description:"Description of the work done"`
+	Started string `long:"started" default:"now" description:"When the work started, e.g. '9:30', 'yesterday 15:00' or '2024-05-01 10:00'"`
+	Args    struct {
+		Issue    string `positional-arg-name:"ISSUE" description:"Key of the issue"`
+		Duration string `positional-arg-name:"DURATION" description:"Time spent, e.g. 1h30m, 2d or 1w 2d"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// WorklogsCommand holds the arguments of the worklogs subcommand
+type WorklogsCommand struct {
+	Args struct {
+		Issue string `positional-arg-name:"ISSUE" description:"Key of the issue"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// TimesheetCommand holds the arguments of the timesheet subcommand
+type TimesheetCommand struct {
+	User string `short:"u" long:"user" default:"me" description:"Name, email or 'me' of the user who logged the time"`
+	Week bool   `short:"w" long:"week" description:"Show the current week (default when no range is given)"`
+	From string `long:"from" description:"First day of the range, e.g. '2024-05-01' or 'yesterday'"`
+	To   string `long:"to" description:"Last day of the range (defaults to today)"`
+}
+
+// PresetsCommand holds the subcommands of the presets subcommand
+type PresetsCommand struct {
+	List PresetsListCommand   `command:"list" description:"List the saved query presets"`
+	Add  PresetsAddCommand    `command:"add" description:"Save a query preset"`
+	Rm   PresetsRemoveCommand `command:"rm" description:"Remove a query preset"`
 }
 
+// PresetsListCommand holds the arguments of the presets list subcommand
+type PresetsListCommand struct{}
+
+// PresetsAddCommand holds the arguments of the presets add subcommand
+type PresetsAddCommand struct {
+	JQL         string `long:"jql" description:"Raw JQL of the preset instead of command line arguments"`
+	Description string `long:"description" description:"Description of the preset"`
+	Args        struct {
+		Name string   `positional-arg-name:"NAME" required:"yes" description:"Name of the preset"`
+		Args []string `positional-arg-name:"ARGS" description:"Query arguments of the preset, after --"`
+	} `positional-args:"yes"`
+}
+
+// PresetsRemoveCommand holds the arguments of the presets rm subcommand
+type PresetsRemoveCommand struct {
+	Args struct {
+		Name string `positional-arg-name:"NAME" description:"Name of the preset"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// ProfilesCommand holds the arguments of the profiles subcommand
+type ProfilesCommand struct{}
+
+// LoginCommand holds the arguments of the login subcommand
+type LoginCommand struct {
+	OAuth        bool   `long:"oauth" description:"Authorize with OAuth 2.0 in the browser instead of an API token"`
+	ClientID     string `long:"client-id" description:"Client ID of the Atlassian OAuth 2.0 app"`
+	ClientSecret string `long:"client-secret" description:"Client secret of the Atlassian OAuth 2.0 app"`
+	RedirectURL  string `long:"redirect-url" default:"http://localhost:8910/callback" description:"Callback URL registered in the OAuth 2.0 app"`
+}
+
+// ConfigCommand holds the subcommands of the config subcommand
+type ConfigCommand struct {
+	Show     ConfigShowCommand     `command:"show" description:"Show the configuration of the profile with its secrets masked"`
+	Get      ConfigGetCommand      `command:"get" description:"Print the value of a configuration key"`
+	Set      ConfigSetCommand      `command:"set" description:"Change the value of a configuration key"`
+	Edit     ConfigEditCommand     `command:"edit" description:"Open the configuration file in $EDITOR"`
+	Validate ConfigValidateCommand `command:"validate" description:"Check the credentials of the profile against Jira"`
+}
+
+// ConfigShowCommand holds the arguments of the config show subcommand
+type ConfigShowCommand struct{}
+
+// ConfigGetCommand holds the arguments of the config get subcommand
+type ConfigGetCommand struct {
+	Args struct {
+		Key string `positional-arg-name:"KEY" description:"Configuration key, e.g. jira.base_url or project"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// ConfigSetCommand holds the arguments of the config set subcommand
+type ConfigSetCommand struct {
+	Args struct {
+		Key   string `positional-arg-name:"KEY" description:"Configuration key, e.g. jira.base_url or project"`
+		Value string `positional-arg-name:"VALUE" description:"New value of the key"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// ConfigEditCommand holds the arguments of the config edit subcommand
+type ConfigEditCommand struct{}
+
+// ConfigValidateCommand holds the arguments of the config validate subcommand
+type ConfigValidateCommand struct{}
+
 // ParseFlags parses command-line flags and returns a populated Flags struct
 func ParseFlags() (*Flags, []string, error) {
+	return ParseArgs(os.Args[1:])
+}
+
+// ParseArgs parses the given command-line arguments and returns a populated Flags struct
+func ParseArgs(args []string) (*Flags, []string, error) {
 	var opts Flags
 	var searchTerms []string
-	parser := flags.NewParser(&opts, flags.Default)
-	searchTerms, err := parser.Parse()
+	// Errors are not printed by the parser, as the caller reports them
+	parser := flags.NewParser(&opts, flags.HelpFlag|flags.PassDoubleDash)
+	parser.SubcommandsOptional = true
+	// Commands are only recognized as the first term, so search terms with the name of a
+	// command must follow a -- separator
+	parser.Usage = "[OPTIONS] [[--] SEARCH TERMS...]"
+	searchTerms, err := parser.ParseArgs(args)
 	if flags.WroteHelp(err) {
+		fmt.Println(err)
 		os.Exit(0)
 	}
+	// A command after -s is a search term missing the -- separator
+	if parser.Active != nil && len(opts.Search) > 0 {
+		hint := fmt.Sprintf("search terms with the name of a command must follow --, e.g. 'jrquery -s -- %s'", parser.Active.Name)
+		if err != nil {
+			err = fmt.Errorf("%w (%s)", err, hint)
+		} else {
+			err = fmt.Errorf("%s", hint)
+		}
+	}
+	// Counts are printed instead of running the bulk actions, so they cannot be combined
+	if err == nil && len(opts.Do) > 0 {
+		if opts.Count {
+			err = fmt.Errorf("--do cannot be used with --count")
+		} else if opts.Stats || len(opts.StatsBy) > 0 {
+			err = fmt.Errorf("--do cannot be used with --stats or --stats-by")
+		}
+	}
+
+	// Store the selected subcommand name, including nested ones ("presets add")
+	var names []string
+	for command := parser.Active; command != nil; command = command.Active {
+		names = append(names, command.Name)
+	}
+	opts.Command = strings.Join(names, " ")
+	opts.RawArgs = args
 	return &opts, searchTerms, err
 }
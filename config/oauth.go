@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// Authentication methods of a profile, saved in its auth key.
+const (
+	AuthToken = "token"
+	AuthOAuth = "oauth"
+)
+
+// OAuthConfig holds the OAuth 2.0 (3LO) authorization of a profile. Empty URLs select the
+// Atlassian endpoints.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	CloudID      string
+	AuthURL      string
+	TokenURL     string
+	APIURL       string
+	Token        *oauth2.Token
+}
+
+// oauthSecret holds the secrets of the OAuth authorization, saved together in the secret store.
+type oauthSecret struct {
+	ClientSecret string        `json:"client_secret"`
+	Token        *oauth2.Token `json:"token"`
+}
+
+// oauthAccount returns the account of the OAuth secrets in the secret stores.
+func (o *OAuthConfig) oauthAccount() string {
+	return "oauth:" + o.ClientID
+}
+
+// parseAuth returns the authentication method of the given name, token by default.
+func parseAuth(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", AuthToken:
+		return AuthToken, nil
+	case AuthOAuth:
+		return AuthOAuth, nil
+	}
+	return "", fmt.Errorf("invalid authentication method %q, use token or oauth", name)
+}
+
+// loadOAuth reads the OAuth authorization of a profile and its secrets.
+func loadOAuth(profile string) (OAuthConfig, error) {
+	prefix := profileKey(profile) + "jira.oauth."
+	oauth := OAuthConfig{
+		ClientID: viper.GetString(prefix + "client_id"),
+		CloudID:  viper.GetString(prefix + "cloud_id"),
+		AuthURL:  viper.GetString(prefix + "auth_url"),
+		TokenURL: viper.GetString(prefix + "token_url"),
+		APIURL:   viper.GetString(prefix + "api_url"),
+	}
+	if oauth.ClientID == "" {
+		return oauth, nil
+	}
+
+	data, err := loadSecret(profile, oauth.oauthAccount(), "jira.oauth.credentials")
+	if err != nil || data == "" {
+		return oauth, err
+	}
+	var secret oauthSecret
+	if err := json.Unmarshal([]byte(data), &secret); err != nil {
+		return oauth, fmt.Errorf("error decoding OAuth credentials: %w", err)
+	}
+	oauth.ClientSecret = secret.ClientSecret
+	oauth.Token = secret.Token
+	return oauth, nil
+}
+
+// saveOAuth saves the OAuth authorization of a profile, with its secrets in the secret store.
+func saveOAuth(cfg *Config) error {
+	prefix := profileKey(cfg.Profile) + "jira.oauth."
+	fileConfig.Set(prefix+"client_id", cfg.OAuth.ClientID)
+	fileConfig.Set(prefix+"cloud_id", cfg.OAuth.CloudID)
+	for key, value := range map[string]string{"auth_url": cfg.OAuth.AuthURL, "token_url": cfg.OAuth.TokenURL, "api_url": cfg.OAuth.APIURL} {
+		if value != "" || fileConfig.IsSet(prefix+key) {
+			fileConfig.Set(prefix+key, value)
+		}
+	}
+
+	data, err := json.Marshal(oauthSecret{ClientSecret: cfg.OAuth.ClientSecret, Token: cfg.OAuth.Token})
+	if err != nil {
+		return fmt.Errorf("error encoding OAuth credentials: %w", err)
+	}
+	return saveSecret(cfg.Profile, cfg.OAuth.oauthAccount(), "jira.oauth.credentials", string(data))
+}
+
+// SaveOAuth saves the configuration of a profile together with its OAuth authorization,
+// such as the refreshed tokens.
+func SaveOAuth(cfg *Config) error {
+	cfg.Auth = AuthOAuth
+	cfg.saveOAuth = true
+	return SaveConfig(cfg)
+}
+
+// PromptOAuth returns the configuration of a profile for a new OAuth authorization, keeping
+// its current settings and prompting for the client ID and secret of the Atlassian app
+// when not given or already configured. An empty profile selects the same one as LoadConfig.
+func PromptOAuth(profile, clientID, clientSecret string) (*Config, error) {
+
+	// Keep the existing configuration, if any
+	readConfigFile()
+	profile, err := resolveProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	prefix := profileKey(profile)
+
+	oauth, err := loadOAuth(profile)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{
+		Profile:       profile,
+		Deployment:    DeploymentCloud,
+		Auth:          AuthOAuth,
+		JiraBaseURL:   viper.GetString(prefix + "jira.base_url"),
+		JiraUserEmail: viper.GetString(prefix + "jira.user_email"),
+		OAuth:         oauth,
+	}
+	if clientID != "" && clientID != config.OAuth.ClientID {
+		config.OAuth.ClientID = clientID
+		config.OAuth.ClientSecret = ""
+	}
+	if clientSecret != "" {
+		config.OAuth.ClientSecret = clientSecret
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	if config.OAuth.ClientID == "" || config.OAuth.ClientSecret == "" {
+		fmt.Println("You need an OAuth 2.0 (3LO) app with the Jira API and the callback URL of jrquery.")
+		fmt.Println()
+		fmt.Println(theme.Title.Paint("https://developer.atlassian.com/console/myapps/"))
+		fmt.Println()
+	}
+	if config.OAuth.ClientID == "" {
+		fmt.Print("Enter the Client ID: ")
+		id, _ := reader.ReadString('\n')
+		config.OAuth.ClientID = strings.TrimSpace(id)
+	}
+	if config.OAuth.ClientSecret == "" {
+		fmt.Print("Enter the Client secret: ")
+		secret, _ := reader.ReadString('\n')
+		config.OAuth.ClientSecret = strings.TrimSpace(secret)
+	}
+	if config.OAuth.ClientID == "" || config.OAuth.ClientSecret == "" {
+		return nil, fmt.Errorf("the client ID and secret are required")
+	}
+
+	return config, nil
+}
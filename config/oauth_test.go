@@ -0,0 +1,64 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+func TestSaveOAuthRotatedToken(t *testing.T) {
+	tests := []struct {
+		store  string
+		inFile bool
+	}{
+		{store: SecretStoreKeyring},
+		{store: SecretStorePlain, inFile: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.store, func(t *testing.T) {
+			keyring.MockInit()
+			path := setupConfig(t, `{"secret_store": "`+tt.store+`", "profiles": {"work": {"jira": {"base_url": "https://example.atlassian.net", "auth": "oauth", "oauth": {"client_id": "client", "cloud_id": "cloud"}}}}}`)
+
+			cfg := &Config{
+				Profile:     "work",
+				Deployment:  DeploymentCloud,
+				JiraBaseURL: "https://example.atlassian.net",
+				OAuth: OAuthConfig{
+					ClientID:     "client",
+					ClientSecret: "client-secret",
+					CloudID:      "cloud",
+					Token:        &oauth2.Token{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: time.Now().Add(time.Hour)},
+				},
+			}
+			if err := SaveOAuth(cfg); err != nil {
+				t.Fatalf("SaveOAuth() error: %v", err)
+			}
+
+			// A refresh rotates the refresh token, which must replace the saved one
+			cfg.OAuth.Token = &oauth2.Token{AccessToken: "access-2", RefreshToken: "refresh-2", Expiry: time.Now().Add(time.Hour)}
+			if err := SaveOAuth(cfg); err != nil {
+				t.Fatalf("SaveOAuth() error: %v", err)
+			}
+
+			if got := strings.Contains(readFile(t, path), "refresh-2"); got != tt.inFile {
+				t.Errorf("refresh token in config file = %v, want %v", got, tt.inFile)
+			}
+			if err := readConfigFile(); err != nil {
+				t.Fatalf("readConfigFile() error: %v", err)
+			}
+			oauth, err := loadOAuth("work")
+			if err != nil {
+				t.Fatalf("loadOAuth() error: %v", err)
+			}
+			if oauth.Token == nil || oauth.Token.RefreshToken != "refresh-2" || oauth.Token.AccessToken != "access-2" {
+				t.Errorf("loadOAuth() token = %+v, want the rotated one", oauth.Token)
+			}
+			if oauth.ClientSecret != "client-secret" || oauth.CloudID != "cloud" {
+				t.Errorf("loadOAuth() = %+v, want the saved client secret and cloud ID", oauth)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Names of the secret stores. The global secret_store key selects the store of the profiles
+// whose secrets were never saved, and the jira.token_store key of each profile records the
+// store its secrets are in, which keeps being used if secret_store changes later.
+const (
+	SecretStoreAuto    = "auto"
+	SecretStoreKeyring = "keyring"
+	SecretStoreFile    = "file"
+	SecretStorePlain   = "plain"
+)
+
+// keyringService is the service name of the secrets saved in the OS keyring.
+const keyringService = "jrquery"
+
+// passphraseEnv is the environment variable with the passphrase of the encrypted file store.
+const passphraseEnv = "JRQUERY_PASSPHRASE"
+
+// errSecretNotFound is returned when a store has no secret for the given profile.
+var errSecretNotFound = errors.New("secret not found")
+
+// SecretStore keeps the API tokens of the profiles outside the config file.
+type SecretStore interface {
+	// Name returns the name of the store saved in the config file
+	Name() string
+	// Get returns the secret of the given profile and account
+	Get(profile, account string) (string, error)
+	// Set saves the secret of the given profile and account
+	Set(profile, account, secret string) error
+}
+
+// NewSecretStore returns the secret store with the given name. The auto store uses the
+// OS keyring when available and the encrypted file otherwise, which needs a passphrase.
+// The plain store returns nil, meaning the token is kept in the config file.
+func NewSecretStore(name string) (SecretStore, error) {
+	switch name {
+	case SecretStoreKeyring:
+		return &keyringStore{}, nil
+	case SecretStoreFile:
+		return newFileSecretStore()
+	case SecretStorePlain:
+		return nil, nil
+	case "", SecretStoreAuto:
+		if keyringAvailable() {
+			return &keyringStore{}, nil
+		}
+		if os.Getenv(passphraseEnv) == "" {
+			return nil, fmt.Errorf("no OS keyring available, set %s to encrypt the token in a file or run 'jrquery config set secret_store plain' to keep it in the config file", passphraseEnv)
+		}
+		return newFileSecretStore()
+	}
+	return nil, fmt.Errorf("unknown secret store %q, use auto, keyring, file or plain", name)
+}
+
+// keyringStore keeps the secrets in the OS keyring: the freedesktop Secret Service over
+// D-Bus (GNOME Keyring, KWallet...) on Linux, the Keychain on macOS and the Credential
+// Manager on Windows.
+type keyringStore struct{}
+
+// keyringAvailable returns true if the OS keyring answers a lookup.
+func keyringAvailable() bool {
+	_, err := keyring.Get(keyringService, "")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+// secretKey returns the key of the secret of the given profile and account.
+func secretKey(profile, account string) string {
+	return profile + "/" + account
+}
+
+// Name implements the SecretStore interface.
+func (s *keyringStore) Name() string {
+	return SecretStoreKeyring
+}
+
+// Get implements the SecretStore interface.
+func (s *keyringStore) Get(profile, account string) (string, error) {
+	secret, err := keyring.Get(keyringService, secretKey(profile, account))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", errSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading secret from the keyring: %w", err)
+	}
+	return secret, nil
+}
+
+// Set implements the SecretStore interface.
+func (s *keyringStore) Set(profile, account, secret string) error {
+	if err := keyring.Set(keyringService, secretKey(profile, account), secret); err != nil {
+		return fmt.Errorf("error saving secret to the keyring: %w", err)
+	}
+	return nil
+}
+
+// fileSecretStore keeps the secrets in a file encrypted with AES-GCM, with a key derived
+// from the passphrase of the JRQUERY_PASSPHRASE environment variable.
+type fileSecretStore struct {
+	path string
+}
+
+// newFileSecretStore returns the encrypted file store next to the config file.
+func newFileSecretStore() (*fileSecretStore, error) {
+	configPath, err := getUserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return &fileSecretStore{path: filepath.Join(filepath.Dir(configPath), "jrquery.secrets")}, nil
+}
+
+// Name implements the SecretStore interface.
+func (s *fileSecretStore) Name() string {
+	return SecretStoreFile
+}
+
+// Get implements the SecretStore interface.
+func (s *fileSecretStore) Get(profile, account string) (string, error) {
+	secrets, err := s.read()
+	if err != nil {
+		return "", err
+	}
+
+	secret, ok := secrets[secretKey(profile, account)]
+	if !ok {
+		return "", errSecretNotFound
+	}
+	return secret, nil
+}
+
+// Set implements the SecretStore interface.
+func (s *fileSecretStore) Set(profile, account, secret string) error {
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	secrets[secretKey(profile, account)] = secret
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("error encoding secrets: %w", err)
+	}
+
+	// The file holds the salt, the nonce and the encrypted secrets
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("error generating salt: %w", err)
+	}
+	gcm, err := secretCipher(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("error generating nonce: %w", err)
+	}
+	data := append(append(salt, nonce...), gcm.Seal(nil, nonce, plaintext, nil)...)
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("could not create configuration directory: %w", err)
+	}
+	return writePrivateFile(s.path, data)
+}
+
+// read decrypts the secrets file, returning an empty map if it does not exist.
+func (s *fileSecretStore) read() (map[string]string, error) {
+	secrets := make(map[string]string)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading secrets file: %w", err)
+	}
+
+	if len(data) < 16 {
+		return nil, fmt.Errorf("secrets file %s is corrupted", s.path)
+	}
+	gcm, err := secretCipher(data[:16])
+	if err != nil {
+		return nil, err
+	}
+	data = data[16:]
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("secrets file %s is corrupted", s.path)
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt %s, was %s changed?", s.path, passphraseEnv)
+	}
+
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("error decoding secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+// secretCipher returns the AES-GCM cipher for the secrets file with the given salt.
+func secretCipher(salt []byte) (cipher.AEAD, error) {
+	passphrase := os.Getenv(passphraseEnv)
+	if passphrase == "" {
+		return nil, fmt.Errorf("the file secret store needs a passphrase, set it in %s", passphraseEnv)
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, 100000, 32, sha256.New))
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// runTokenCommand runs the token_command of a profile, such as "pass show jira", and
+// returns the first line of its output.
+func runTokenCommand(command string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("error running token command: %s", message)
+		}
+		return "", fmt.Errorf("error running token command: %w", err)
+	}
+
+	token, _, _ := strings.Cut(string(output), "\n")
+	if token = strings.TrimSpace(token); token == "" {
+		return "", fmt.Errorf("token command %q returned an empty token", command)
+	}
+	return token, nil
+}
+
+// writePrivateFile writes data to a file only readable by the current user.
+func writePrivateFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the permissions of existing files
+	return os.Chmod(path, 0o600)
+}
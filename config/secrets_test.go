@@ -0,0 +1,243 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+)
+
+// setupConfig writes a config file with the given content in a temporary home directory
+// and reads it, returning its path.
+func setupConfig(t *testing.T, content string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Reset()
+
+	path := filepath.Join(home, ".config", "jrquery.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := readConfigFile(); err != nil {
+		t.Fatalf("readConfigFile() error: %v", err)
+	}
+	return path
+}
+
+// readFile returns the content of a file, failing the test if it cannot be read.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSecretStoreRoundTrip(t *testing.T) {
+	tests := []struct {
+		store      string
+		wantStore  string
+		passphrase string
+		inFile     bool
+	}{
+		{store: SecretStoreKeyring, wantStore: SecretStoreKeyring},
+		{store: SecretStoreFile, wantStore: SecretStoreFile, passphrase: "correct horse"},
+		{store: SecretStorePlain, wantStore: SecretStorePlain, inFile: true},
+		{store: SecretStoreAuto, wantStore: SecretStoreKeyring},
+	}
+	for _, tt := range tests {
+		t.Run(tt.store, func(t *testing.T) {
+			keyring.MockInit()
+			t.Setenv(passphraseEnv, tt.passphrase)
+			path := setupConfig(t, `{"secret_store": "`+tt.store+`", "profiles": {"work": {"jira": {"base_url": "https://example.net"}}}}`)
+
+			if err := saveSecret("work", "jane@example.net", "jira.api_token", "s3cr3t-token"); err != nil {
+				t.Fatalf("saveSecret() error: %v", err)
+			}
+			if err := writeConfigFile(); err != nil {
+				t.Fatalf("writeConfigFile() error: %v", err)
+			}
+
+			content := readFile(t, path)
+			if got := strings.Contains(content, "s3cr3t-token"); got != tt.inFile {
+				t.Errorf("secret in config file = %v, want %v:\n%s", got, tt.inFile, content)
+			}
+
+			// Read the file again, as a new run would
+			if err := readConfigFile(); err != nil {
+				t.Fatalf("readConfigFile() error: %v", err)
+			}
+			if got := profileSecretStore("work"); got != tt.wantStore {
+				t.Errorf("token_store = %q, want %q", got, tt.wantStore)
+			}
+			secret, err := loadSecret("work", "jane@example.net", "jira.api_token")
+			if err != nil {
+				t.Fatalf("loadSecret() error: %v", err)
+			}
+			if secret != "s3cr3t-token" {
+				t.Errorf("loadSecret() = %q, want %q", secret, "s3cr3t-token")
+			}
+			// The plain store has a single token per profile
+			if tt.inFile {
+				return
+			}
+			if secret, err := loadSecret("work", "other@example.net", "jira.api_token"); err != nil || secret != "" {
+				t.Errorf("loadSecret() of another account = %q, %v, want no secret", secret, err)
+			}
+		})
+	}
+}
+
+func TestSaveSecretUsesProfileStore(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv(passphraseEnv, "passphrase")
+	// The profile keeps its store even if the default store of new profiles changed
+	setupConfig(t, `{"secret_store": "keyring", "jira": {"base_url": "https://example.net", "token_store": "file"}}`)
+
+	if err := saveSecret(DefaultProfile, "jane@example.net", "jira.api_token", "token"); err != nil {
+		t.Fatalf("saveSecret() error: %v", err)
+	}
+	if got := profileSecretStore(DefaultProfile); got != SecretStoreFile {
+		t.Errorf("token_store = %q, want %q", got, SecretStoreFile)
+	}
+	if _, err := (&keyringStore{}).Get(DefaultProfile, "jane@example.net"); err != errSecretNotFound {
+		t.Errorf("keyring has the secret, want it only in the file store (error %v)", err)
+	}
+	secret, err := loadSecret(DefaultProfile, "jane@example.net", "jira.api_token")
+	if err != nil || secret != "token" {
+		t.Errorf("loadSecret() = %q, %v, want %q", secret, err, "token")
+	}
+}
+
+func TestFileSecretStore(t *testing.T) {
+	t.Setenv(passphraseEnv, "first passphrase")
+	store := &fileSecretStore{path: filepath.Join(t.TempDir(), "jrquery.secrets")}
+
+	if _, err := store.Get("default", "jane"); err != errSecretNotFound {
+		t.Errorf("Get() without file error = %v, want %v", err, errSecretNotFound)
+	}
+	if err := store.Set("default", "jane", "one"); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if err := store.Set("work", "jane", "two"); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	for profile, want := range map[string]string{"default": "one", "work": "two"} {
+		if got, err := store.Get(profile, "jane"); err != nil || got != want {
+			t.Errorf("Get(%q) = %q, %v, want %q", profile, got, err, want)
+		}
+	}
+	if strings.Contains(readFile(t, store.path), "one") {
+		t.Error("secrets file is not encrypted")
+	}
+
+	t.Setenv(passphraseEnv, "second passphrase")
+	if _, err := store.Get("default", "jane"); err == nil {
+		t.Error("Get() with another passphrase did not fail")
+	}
+	t.Setenv(passphraseEnv, "")
+	if _, err := store.Get("default", "jane"); err == nil || !strings.Contains(err.Error(), passphraseEnv) {
+		t.Errorf("Get() without passphrase error = %v, want it to ask for %s", err, passphraseEnv)
+	}
+	if err := store.Set("default", "jane", "three"); err == nil {
+		t.Error("Set() without passphrase did not fail")
+	}
+}
+
+func TestPrivateFileMode(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv(passphraseEnv, "passphrase")
+	path := setupConfig(t, `{"secret_store": "file", "jira": {"base_url": "https://example.net"}}`)
+
+	secretsPath := filepath.Join(filepath.Dir(path), "jrquery.secrets")
+	if err := saveSecret(DefaultProfile, "jane@example.net", "jira.api_token", "token"); err != nil {
+		t.Fatalf("saveSecret() error: %v", err)
+	}
+	if err := writeConfigFile(); err != nil {
+		t.Fatalf("writeConfigFile() error: %v", err)
+	}
+	// Existing files are made private too
+	if err := os.Chmod(secretsPath, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveSecret(DefaultProfile, "jane@example.net", "jira.api_token", "token2"); err != nil {
+		t.Fatalf("saveSecret() error: %v", err)
+	}
+
+	for _, file := range []string{path, secretsPath} {
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode := info.Mode().Perm(); mode != 0o600 {
+			t.Errorf("mode of %s = %o, want 600", filepath.Base(file), mode)
+		}
+	}
+}
+
+func TestTokenCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		wantErr bool
+	}{
+		{name: "first line", command: `printf 'tok3n\nsecond line\n'`, want: "tok3n"},
+		{name: "trimmed", command: `echo '  tok3n  '`, want: "tok3n"},
+		{name: "empty output", command: `true`, wantErr: true},
+		{name: "failure", command: `echo denied >&2; exit 1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupConfig(t, `{"profiles": {"work": {"jira": {"base_url": "https://example.net", "token_command": "`+strings.ReplaceAll(tt.command, `\`, `\\`)+`"}}}}`)
+
+			got, err := loadToken("work", "jane@example.net")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("loadToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveConfigSkipsEnvironment(t *testing.T) {
+	t.Setenv("JIRA_API_TOKEN", "env-token")
+	t.Setenv("JIRA_BASE_URL", "https://env.example.net")
+	t.Setenv("JIRA_USER_EMAIL", "env@example.net")
+	path := setupConfig(t, `{"jira": {"base_url": "https://file.example.net", "user_email": "file@example.net", "token_store": "plain", "api_token": ""}}`)
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if cfg.JiraAPIToken != "env-token" || cfg.JiraBaseURL != "https://env.example.net" {
+		t.Fatalf("LoadConfig() did not use the environment: %+v", cfg)
+	}
+
+	cfg.Presets["mine"] = Preset{Args: []string{"-u", "me"}}
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error: %v", err)
+	}
+
+	content := readFile(t, path)
+	for _, value := range []string{"env-token", "env.example.net", "env@example.net"} {
+		if strings.Contains(content, value) {
+			t.Errorf("config file has the environment value %q:\n%s", value, content)
+		}
+	}
+	for _, value := range []string{"https://file.example.net", "file@example.net", `"mine"`} {
+		if !strings.Contains(content, value) {
+			t.Errorf("config file lost %q:\n%s", value, content)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"irontec.com/jrquery/internal/theme"
+)
+
+// Setting is a configuration key of a profile and its value, as printed by config show.
+// Secret values are masked and Source tells where the value comes from, if not the file.
+type Setting struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// globalSettings are the keys shared by all the profiles, stored at the top level of the config file.
+var globalSettings = map[string]bool{
+	"default_profile":             true,
+	"secret_store":                true,
+	"colors.status.new":           true,
+	"colors.status.indeterminate": true,
+	"colors.status.done":          true,
+}
+
+// settings are the keys that can be changed with SetSetting, with the function that checks their values.
+var settings = map[string]func(value string) error{
+	"default_profile": func(value string) error {
+		_, err := resolveProfile(value)
+		return err
+	},
+	"secret_store": checkSecretStore,
+	"jira.base_url": func(value string) error {
+		if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return fmt.Errorf("invalid URL %q, it must start with https://", value)
+		}
+		return nil
+	},
+	"jira.user_email": nil,
+	"jira.api_token":  nil,
+	"jira.deployment": func(value string) error {
+		_, err := parseDeployment(value)
+		return err
+	},
+	"jira.auth": func(value string) error {
+		_, err := parseAuth(value)
+		return err
+	},
+	"jira.token_store":            checkSecretStore,
+	"jira.token_command":          nil,
+	"jira.oauth.client_id":        nil,
+	"jira.oauth.cloud_id":         nil,
+	"jira.oauth.auth_url":         nil,
+	"jira.oauth.token_url":        nil,
+	"jira.oauth.api_url":          nil,
+	"project":                     nil,
+	"colors.status.new":           checkStyle,
+	"colors.status.indeterminate": checkStyle,
+	"colors.status.done":          checkStyle,
+}
+
+// checkSecretStore checks the value of a secret store setting.
+func checkSecretStore(value string) error {
+	switch value {
+	case SecretStoreAuto, SecretStoreKeyring, SecretStoreFile, SecretStorePlain:
+		return nil
+	}
+	return fmt.Errorf("invalid secret store %q, use auto, keyring, file or plain", value)
+}
+
+// checkStyle checks the value of a color setting.
+func checkStyle(value string) error {
+	_, err := theme.ParseStyle(value)
+	return err
+}
+
+// envSettings are the keys of a profile that can be overridden by environment variables.
+var envSettings = map[string]string{
+	"jira.base_url":   "JIRA_BASE_URL",
+	"jira.api_token":  "JIRA_API_TOKEN",
+	"jira.user_email": "JIRA_USER_EMAIL",
+	"jira.deployment": "JIRA_DEPLOYMENT",
+}
+
+// ConfigPath returns the path of the configuration file.
+func ConfigPath() (string, error) {
+	return getUserConfigPath()
+}
+
+// settingKey returns the viper key of a setting in the given profile.
+func settingKey(profile, key string) string {
+	if globalSettings[key] {
+		return key
+	}
+	return profileKey(profile) + key
+}
+
+// Settings returns the resolved profile and its settings in the config file, sorted by key,
+// with the secrets masked. An empty profile selects the same one as LoadConfig.
+func Settings(profile string) (string, []Setting, error) {
+	if err := readConfigFile(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", nil, err
+	}
+	profile, err := resolveProfile(profile)
+	if err != nil {
+		return "", nil, err
+	}
+	prefix := profileKey(profile)
+
+	var list []Setting
+	for _, key := range fileConfig.AllKeys() {
+		name := key
+		switch {
+		case globalSettings[key] || strings.HasPrefix(key, "colors."):
+		case prefix == "" && strings.HasPrefix(key, "profiles."):
+			continue
+		case strings.HasPrefix(key, prefix):
+			name = strings.TrimPrefix(key, prefix)
+		default:
+			continue
+		}
+		// Presets are listed by jrquery presets list
+		if strings.HasPrefix(name, "presets.") {
+			continue
+		}
+		list = append(list, Setting{Key: name, Value: fmt.Sprint(fileConfig.Get(key))})
+	}
+
+	// Environment variables override the file values
+	for key, env := range envSettings {
+		if value, ok := os.LookupEnv(env); ok {
+			list = setSetting(list, Setting{Key: key, Value: value, Source: "$" + env})
+		}
+	}
+
+	// Secrets are shown masked, including the ones kept in a secret store
+	for i, setting := range list {
+		switch {
+		case setting.Value == "":
+		case setting.Key == "jira.api_token":
+			list[i].Value = MaskSecret(setting.Value)
+		case setting.Key == "jira.oauth.credentials":
+			list[i].Value = MaskSecret("")
+		}
+	}
+	if store := profileSecretStore(profile); store != "" && store != SecretStorePlain {
+		account := fileConfig.GetString(prefix + "jira.user_email")
+		if account == "" {
+			account = fileConfig.GetString(prefix + "jira.base_url")
+		}
+		if fileConfig.GetString(prefix+"jira.token_command") == "" && os.Getenv("JIRA_API_TOKEN") == "" {
+			list = storedSetting(list, profile, "jira.api_token", account, store)
+		}
+		if clientID := fileConfig.GetString(prefix + "jira.oauth.client_id"); clientID != "" {
+			list = storedSetting(list, profile, "jira.oauth.credentials", (&OAuthConfig{ClientID: clientID}).oauthAccount(), store)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return profile, list, nil
+}
+
+// setSetting replaces the setting with the same key in the list, or appends it.
+func setSetting(list []Setting, setting Setting) []Setting {
+	for i := range list {
+		if list[i].Key == setting.Key {
+			list[i] = setting
+			return list
+		}
+	}
+	return append(list, setting)
+}
+
+// storedSetting adds to the list the masked secret of a profile kept in a secret store.
+func storedSetting(list []Setting, profile, key, account, store string) []Setting {
+	source := store + " store"
+	secret, err := loadSecret(profile, account, "")
+	switch {
+	case err != nil:
+		return setSetting(list, Setting{Key: key, Value: fmt.Sprintf("error: %v", err), Source: source})
+	case secret == "":
+		return list
+	case key == "jira.api_token":
+		return setSetting(list, Setting{Key: key, Value: MaskSecret(secret), Source: source})
+	}
+	return setSetting(list, Setting{Key: key, Value: MaskSecret(""), Source: source})
+}
+
+// MaskSecret hides a secret, keeping only its last characters when it is long enough
+// to identify it without revealing it.
+func MaskSecret(secret string) string {
+	if len(secret) < 16 {
+		return "********"
+	}
+	return "********" + secret[len(secret)-4:]
+}
+
+// GetSetting returns the value of a key of a profile in the config file. Values that are
+// not strings, such as the presets, are returned as JSON.
+func GetSetting(profile, key string) (string, error) {
+	if err := readConfigFile(); err != nil {
+		return "", err
+	}
+	profile, err := resolveProfile(profile)
+	if err != nil {
+		return "", err
+	}
+
+	key = strings.ToLower(key)
+	if !fileConfig.IsSet(settingKey(profile, key)) {
+		return "", fmt.Errorf("key %q is not set in profile %q", key, profile)
+	}
+	value := fileConfig.Get(settingKey(profile, key))
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error encoding %q: %w", key, err)
+	}
+	return string(data), nil
+}
+
+// SetSetting changes the value of a key of a profile and saves the config file. The API
+// token is saved in the secret store, like the prompted ones.
+func SetSetting(profile, key, value string) error {
+	if err := readConfigFile(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	profile, err := resolveProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	key = strings.ToLower(key)
+	check, ok := settings[key]
+	// Column presets are saved as columns.NAME
+	if name, found := strings.CutPrefix(key, "columns."); found {
+		if !profileName.MatchString(name) {
+			return fmt.Errorf("invalid column preset name %q, use only letters, digits, hyphens and underscores", name)
+		}
+		ok = true
+	}
+	if !ok {
+		keys := make([]string, 0, len(settings))
+		for name := range settings {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+		return fmt.Errorf("unknown key %q, valid keys: %s, columns.NAME", key, strings.Join(keys, ", "))
+	}
+	if check != nil && value != "" {
+		if err := check(value); err != nil {
+			return err
+		}
+	}
+
+	if key == "jira.api_token" {
+		prefix := profileKey(profile)
+		cfg := &Config{
+			Profile:       profile,
+			JiraBaseURL:   fileConfig.GetString(prefix + "jira.base_url"),
+			JiraUserEmail: fileConfig.GetString(prefix + "jira.user_email"),
+			JiraAPIToken:  value,
+		}
+		if err := saveToken(cfg); err != nil {
+			return err
+		}
+	} else {
+		fileConfig.Set(settingKey(profile, key), value)
+	}
+	return writeConfigFile()
+}
@@ -0,0 +1,444 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"irontec.com/jrquery/internal/theme"
+)
+
+// ADFNode is a node of an Atlassian Document Format document, as returned by the
+// cloud v3 API for descriptions, comments and other rich text fields.
+type ADFNode struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Marks   []ADFMark      `json:"marks,omitempty"`
+	Content []ADFNode      `json:"content,omitempty"`
+}
+
+// ADFMark is a text formatting mark (bold, link, code...) of an ADF text node.
+type ADFMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// ParseADF decodes an ADF document from its JSON representation.
+func ParseADF(data []byte) (*ADFNode, error) {
+	var doc ADFNode
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error decoding ADF document: %w", err)
+	}
+	return &doc, nil
+}
+
+// NewADFText returns an ADF document with the given plain text, as found in the rich text
+// fields of Server, with a paragraph for each block of lines separated by blank lines.
+func NewADFText(text string) *ADFNode {
+	doc := &ADFNode{Type: "doc"}
+	for _, block := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		paragraph := ADFNode{Type: "paragraph"}
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				paragraph.Content = append(paragraph.Content, ADFNode{Type: "hardBreak"})
+			}
+			paragraph.Content = append(paragraph.Content, ADFNode{Type: "text", Text: line})
+		}
+		doc.Content = append(doc.Content, paragraph)
+	}
+	return doc
+}
+
+// UnmarshalJSON decodes an ADF node, accepting also the plain text strings that Server
+// returns instead of ADF documents.
+func (n *ADFNode) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*n = *NewADFText(text)
+		return nil
+	}
+
+	// Decode with a type without this method to avoid recursion
+	type adfNode ADFNode
+	return json.Unmarshal(data, (*adfNode)(n))
+}
+
+// IsEmpty returns true if the document has no visible content.
+func (n *ADFNode) IsEmpty() bool {
+	return len(n.Render(detailWidth)) == 0
+}
+
+// PlainText converts the ADF node into unwrapped text without terminal styles.
+func (n *ADFNode) PlainText() string {
+	return stripEscapes(strings.Join(n.Render(math.MaxInt32), "\n"))
+}
+
+// Render converts the ADF node into terminal lines wrapped to the given width.
+func (n *ADFNode) Render(width int) []string {
+	if n == nil {
+		return nil
+	}
+	if width < 20 {
+		width = 20
+	}
+
+	var lines []string
+	if n.Type == "doc" {
+		lines = renderADFBlocks(n.Content, width, true)
+	} else {
+		lines = renderADFBlock(*n, width)
+	}
+
+	// Remove leading and trailing empty lines
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// renderADFBlocks renders a sequence of block nodes, optionally separating them with empty lines.
+func renderADFBlocks(nodes []ADFNode, width int, spaced bool) []string {
+	var lines []string
+	for i, node := range nodes {
+		if spaced && i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, renderADFBlock(node, width)...)
+	}
+	return lines
+}
+
+// renderADFBlock renders a single block node into wrapped lines.
+func renderADFBlock(node ADFNode, width int) []string {
+	switch node.Type {
+	case "paragraph":
+		return wrapText(renderADFInline(node.Content), width)
+
+	case "heading":
+		level := adfAttrInt(node.Attrs, "level", 1)
+		text := renderADFInline(node.Content)
+		var lines []string
+		for _, line := range wrapText(text, width) {
+			if level <= 2 {
+				lines = append(lines, theme.Join(theme.Bold, theme.Underline).Paint(line))
+			} else {
+				lines = append(lines, theme.Bold.Paint(line))
+			}
+		}
+		return lines
+
+	case "bulletList":
+		var lines []string
+		for _, item := range node.Content {
+			lines = append(lines, prefixLines(renderADFBlocks(item.Content, width-2, false), "• ", "  ")...)
+		}
+		return lines
+
+	case "orderedList":
+		var lines []string
+		order := adfAttrInt(node.Attrs, "order", 1)
+		numberWidth := len(strconv.Itoa(order+len(node.Content)-1)) + 2
+		for i, item := range node.Content {
+			marker := fmt.Sprintf("%-*s", numberWidth, fmt.Sprintf("%d.", order+i))
+			lines = append(lines, prefixLines(renderADFBlocks(item.Content, width-numberWidth, false), marker, strings.Repeat(" ", numberWidth))...)
+		}
+		return lines
+
+	case "taskList":
+		var lines []string
+		for _, item := range node.Content {
+			marker := "[ ] "
+			if adfAttrString(item.Attrs, "state") == "DONE" {
+				marker = "[x] "
+			}
+			lines = append(lines, prefixLines(wrapText(renderADFInline(item.Content), width-4), marker, "    ")...)
+		}
+		return lines
+
+	case "decisionList":
+		var lines []string
+		for _, item := range node.Content {
+			lines = append(lines, prefixLines(wrapText(renderADFInline(item.Content), width-2), "◆ ", "  ")...)
+		}
+		return lines
+
+	case "codeBlock":
+		var lines []string
+		if language := adfAttrString(node.Attrs, "language"); language != "" {
+			lines = append(lines, theme.Dim.Paint(language))
+		}
+		code := strings.TrimRight(adfPlainText(node.Content), "\n")
+		for _, line := range strings.Split(code, "\n") {
+			lines = append(lines, "  "+theme.Code.Paint(line))
+		}
+		return lines
+
+	case "blockquote":
+		quote := theme.Dim.Paint("│") + " "
+		return prefixLines(renderADFBlocks(node.Content, width-2, true), quote, quote)
+
+	case "panel":
+		panelType := adfAttrString(node.Attrs, "panelType")
+		style, ok := adfPanelStyles[panelType]
+		if !ok {
+			style = adfPanelStyles["info"]
+		}
+		bar := style.Paint("┃") + " "
+		header := theme.Join(style, theme.Bold).Paint(strings.ToUpper(panelType))
+		lines := []string{bar + header}
+		lines = append(lines, prefixLines(renderADFBlocks(node.Content, width-2, true), bar, bar)...)
+		return lines
+
+	case "expand", "nestedExpand":
+		lines := []string{"▸ " + theme.Bold.Paint(adfAttrString(node.Attrs, "title"))}
+		return append(lines, prefixLines(renderADFBlocks(node.Content, width-2, true), "  ", "  ")...)
+
+	case "rule":
+		return []string{strings.Repeat("─", min(width, detailWidth))}
+
+	case "table":
+		return renderADFTable(node, width)
+
+	case "mediaSingle", "mediaGroup":
+		var lines []string
+		for _, media := range node.Content {
+			lines = append(lines, renderADFBlock(media, width)...)
+		}
+		return lines
+
+	case "media":
+		name := adfAttrString(node.Attrs, "alt")
+		if name == "" {
+			name = adfAttrString(node.Attrs, "id")
+		}
+		return []string{theme.Dim.Paint("[attachment: " + name + "]")}
+
+	case "blockCard", "embedCard":
+		return []string{theme.Link.Paint(adfAttrString(node.Attrs, "url"))}
+	}
+
+	// Unknown blocks are rendered as their inline content
+	if len(node.Content) > 0 {
+		return renderADFBlocks(node.Content, width, false)
+	}
+	return wrapText(renderADFInline([]ADFNode{node}), width)
+}
+
+// adfPanelStyles maps panel types to their terminal style.
+var adfPanelStyles = map[string]theme.Style{
+	"info":    "34",
+	"note":    "35",
+	"warning": "33",
+	"error":   "31",
+	"success": "32",
+}
+
+// adfMarkStyles maps text marks to the styles that turn them on and off. Marks are turned
+// off without a full reset, so they can be nested.
+var adfMarkStyles = map[string][2]theme.Style{
+	"strong":    {theme.Bold, "22"},
+	"em":        {theme.Italic, "23"},
+	"underline": {theme.Underline, "24"},
+	"strike":    {theme.Strike, "29"},
+	"code":      {theme.Code, "39"},
+	"link":      {theme.Link, "24;39"},
+}
+
+// renderADFInline renders inline nodes (text, mentions, emoji...) into a single styled string.
+func renderADFInline(nodes []ADFNode) string {
+	var sb strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			sb.WriteString(applyADFMarks(node.Text, node.Marks))
+		case "hardBreak":
+			sb.WriteString("\n")
+		case "mention":
+			text := adfAttrString(node.Attrs, "text")
+			if !strings.HasPrefix(text, "@") {
+				text = "@" + text
+			}
+			sb.WriteString(theme.Accent.Paint(text))
+		case "emoji":
+			text := adfAttrString(node.Attrs, "text")
+			if text == "" {
+				text = adfAttrString(node.Attrs, "shortName")
+			}
+			sb.WriteString(text)
+		case "inlineCard":
+			sb.WriteString(theme.Link.Paint(adfAttrString(node.Attrs, "url")))
+		case "status":
+			sb.WriteString(theme.Bold.Paint("[" + strings.ToUpper(adfAttrString(node.Attrs, "text")) + "]"))
+		case "date":
+			sb.WriteString(formatADFDate(adfAttrString(node.Attrs, "timestamp")))
+		default:
+			sb.WriteString(renderADFInline(node.Content))
+		}
+	}
+	return sb.String()
+}
+
+// applyADFMarks wraps text with the terminal styles of the given marks.
+func applyADFMarks(text string, marks []ADFMark) string {
+	link := ""
+	for _, mark := range marks {
+		if mark.Type == "link" {
+			link = adfAttrString(mark.Attrs, "href")
+		}
+		if styles, ok := adfMarkStyles[mark.Type]; ok {
+			text = styles[0].Code() + text + styles[1].Code()
+		}
+	}
+
+	// Show the link target unless the text already is the URL
+	if link != "" && !strings.Contains(text, link) {
+		text += " (" + link + ")"
+	}
+	return text
+}
+
+// renderADFTable renders a table node with aligned columns.
+func renderADFTable(node ADFNode, width int) []string {
+	var rows [][]string
+	var header []bool
+	columns := 0
+
+	for _, row := range node.Content {
+		var cells []string
+		isHeader := len(row.Content) > 0
+		for _, cell := range row.Content {
+			var parts []string
+			for _, block := range cell.Content {
+				parts = append(parts, strings.Join(renderADFBlock(block, width), " "))
+			}
+			cells = append(cells, strings.Join(parts, " "))
+			if cell.Type != "tableHeader" {
+				isHeader = false
+			}
+		}
+		if len(cells) > columns {
+			columns = len(cells)
+		}
+		rows = append(rows, cells)
+		header = append(header, isHeader)
+	}
+
+	// Calculate each column width, sharing the available width if the table is too wide
+	widths := make([]int, columns)
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], visibleWidth(cell))
+		}
+	}
+	available := width - 3*columns - 1
+	for total := sum(widths); total > available && available > columns; total = sum(widths) {
+		widest := 0
+		for i := range widths {
+			if widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		widths[widest]--
+	}
+
+	separator := "+"
+	for _, w := range widths {
+		separator += strings.Repeat("-", w+2) + "+"
+	}
+
+	lines := []string{separator}
+	for r, row := range rows {
+		line := "|"
+		for i := 0; i < columns; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = truncateText(row[i], widths[i])
+			}
+			if header[r] {
+				cell = theme.Bold.Paint(cell)
+			}
+			line += " " + cell + strings.Repeat(" ", widths[i]-visibleWidth(cell)) + " |"
+		}
+		lines = append(lines, line)
+		if header[r] {
+			lines = append(lines, separator)
+		}
+	}
+	return append(lines, separator)
+}
+
+// adfPlainText returns the unstyled text of the given nodes.
+func adfPlainText(nodes []ADFNode) string {
+	var sb strings.Builder
+	for _, node := range nodes {
+		if node.Type == "hardBreak" {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(node.Text)
+		sb.WriteString(adfPlainText(node.Content))
+	}
+	return sb.String()
+}
+
+// adfAttrString returns a string attribute of an ADF node or an empty string.
+func adfAttrString(attrs map[string]any, name string) string {
+	switch value := attrs[name].(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
+
+// adfAttrInt returns an integer attribute of an ADF node or the given default.
+func adfAttrInt(attrs map[string]any, name string, def int) int {
+	if value, ok := attrs[name].(float64); ok {
+		return int(value)
+	}
+	return def
+}
+
+// formatADFDate formats an ADF date node timestamp (milliseconds since epoch).
+func formatADFDate(timestamp string) string {
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return timestamp
+	}
+	return time.UnixMilli(ms).UTC().Format("02-01-2006")
+}
+
+// prefixLines prefixes the first line with first and the remaining ones with rest.
+func prefixLines(lines []string, first, rest string) []string {
+	result := make([]string, 0, len(lines))
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" && i > 0 {
+			prefix = strings.TrimRight(prefix, " ")
+		}
+		result = append(result, prefix+line)
+	}
+	return result
+}
+
+// sum returns the sum of the given integers.
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderADF(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			doc: `{"type": "doc", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "first"}, {"type": "hardBreak"}, {"type": "text", "text": "line"}]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "second", "marks": [{"type": "strong"}]}]}
+			]}`,
+			want: "first\nline\n\nsecond",
+		},
+		{
+			name: "nested bullet list",
+			doc: `{"type": "doc", "content": [{"type": "bulletList", "content": [
+				{"type": "listItem", "content": [
+					{"type": "paragraph", "content": [{"type": "text", "text": "one"}]},
+					{"type": "bulletList", "content": [
+						{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "nested"}]}]}
+					]}
+				]},
+				{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "two"}]}]}
+			]}]}`,
+			want: "• one\n  • nested\n• two",
+		},
+		{
+			name: "ordered list",
+			doc: `{"type": "doc", "content": [{"type": "orderedList", "attrs": {"order": 9}, "content": [
+				{"type": "listItem", "content": [
+					{"type": "paragraph", "content": [{"type": "text", "text": "nine"}]},
+					{"type": "orderedList", "content": [
+						{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "sub"}]}]}
+					]}
+				]},
+				{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "ten"}]}]}
+			]}]}`,
+			want: "9.  nine\n    1. sub\n10. ten",
+		},
+		{
+			name: "code block",
+			doc: `{"type": "doc", "content": [{"type": "codeBlock", "attrs": {"language": "go"}, "content": [
+				{"type": "text", "text": "if x {\n\treturn\n}\n"}
+			]}]}`,
+			want: "go\n  if x {\n  \treturn\n  }",
+		},
+		{
+			name: "table",
+			doc: `{"type": "doc", "content": [{"type": "table", "content": [
+				{"type": "tableRow", "content": [
+					{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Key"}]}]},
+					{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Summary"}]}]}
+				]},
+				{"type": "tableRow", "content": [
+					{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "ABC-1"}]}]},
+					{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Login"}]}]}
+				]}
+			]}]}`,
+			want: "+-------+---------+\n| Key   | Summary |\n+-------+---------+\n| ABC-1 | Login   |\n+-------+---------+",
+		},
+		{
+			name: "mention",
+			doc: `{"type": "doc", "content": [{"type": "paragraph", "content": [
+				{"type": "text", "text": "thanks "},
+				{"type": "mention", "attrs": {"id": "a1", "text": "@Jane Doe"}},
+				{"type": "text", "text": " and "},
+				{"type": "mention", "attrs": {"id": "a2", "text": "Bob"}}
+			]}]}`,
+			want: "thanks @Jane Doe and @Bob",
+		},
+		{
+			name: "link mark",
+			doc: `{"type": "doc", "content": [{"type": "paragraph", "content": [
+				{"type": "text", "text": "see the docs", "marks": [{"type": "link", "attrs": {"href": "https://example.net/docs"}}]},
+				{"type": "text", "text": " or "},
+				{"type": "text", "text": "https://example.net", "marks": [{"type": "link", "attrs": {"href": "https://example.net"}}]}
+			]}]}`,
+			want: "see the docs (https://example.net/docs) or https://example.net",
+		},
+		{
+			name: "unknown block",
+			doc: `{"type": "doc", "content": [
+				{"type": "futureBlock", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "inside"}]}]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "after"}]}
+			]}`,
+			want: "inside\n\nafter",
+		},
+		{
+			name: "unknown inline",
+			doc: `{"type": "doc", "content": [{"type": "paragraph", "content": [
+				{"type": "text", "text": "a "},
+				{"type": "futureInline", "content": [{"type": "text", "text": "b"}]}
+			]}]}`,
+			want: "a b",
+		},
+		{
+			name: "server plain text",
+			doc:  `"first\r\nline\n\nsecond"`,
+			want: "first\nline\n\nsecond",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseADF([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("ParseADF() error: %v", err)
+			}
+			got := stripEscapes(strings.Join(doc.Render(80), "\n"))
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/andygrunwald/go-jira/v2/cloud"
+	"github.com/andygrunwald/go-jira/v2/onpremise"
 )
 
 // Client struct encapsulates the Jira API client from go-jira library.
 type Client struct {
 	apiClient *cloud.Client
+	// server is set for Jira Server and Data Center, which lack the cloud only endpoints
+	server bool
 }
 
-// NewClient initializes a new Jira client using the go-jira library.
+// NewClient initializes a new Jira Cloud client using the go-jira library.
 func NewClient(baseURL, apiToken, userEmail string) (*Client, error) {
 	if baseURL == "" || apiToken == "" || userEmail == "" {
 		return nil, fmt.Errorf("baseURL, apiToken, and userEmail must be provided")
@@ -33,6 +39,57 @@ func NewClient(baseURL, apiToken, userEmail string) (*Client, error) {
 	return &Client{apiClient: apiClient}, nil
 }
 
+// NewServerClient initializes a new Jira Server or Data Center client authenticated with a
+// Personal Access Token. The cloud API client is shared, as the v2 API is mostly the same.
+func NewServerClient(baseURL, token string) (*Client, error) {
+	if baseURL == "" || token == "" {
+		return nil, fmt.Errorf("baseURL and token must be provided")
+	}
+
+	tp := onpremise.PATAuthTransport{Token: token}
+	apiClient, err := cloud.NewClient(baseURL, tp.Client())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira client: %w", err)
+	}
+
+	return &Client{apiClient: apiClient, server: true}, nil
+}
+
+// IsServer returns true if the client connects to Jira Server or Data Center.
+func (c *Client) IsServer() bool {
+	return c.server
+}
+
+// UserID returns the identifier of a user in the API: the account ID on Cloud and the
+// username on Server.
+func (c *Client) UserID(user *cloud.User) string {
+	if c.server {
+		return user.Name
+	}
+	return user.AccountID
+}
+
+// UserRef returns the value of a user field for the given user identifier.
+func (c *Client) UserRef(userID string) map[string]string {
+	return map[string]string{c.userKey(): userID}
+}
+
+// userKey returns the key identifying users in the field values.
+func (c *Client) userKey() string {
+	if c.server {
+		return "name"
+	}
+	return "accountId"
+}
+
+// EpicField returns the JQL field that links issues with their epic.
+func (c *Client) EpicField() string {
+	if c.server {
+		return "\"Epic Link\""
+	}
+	return "parent"
+}
+
 // GetIssue retrieves a specific Jira issue by its key.
 func (c *Client) GetIssue(ctx context.Context, issueKey string) (*cloud.Issue, error) {
 	issue, _, err := c.apiClient.Issue.Get(ctx, issueKey, nil)
@@ -43,6 +100,333 @@ func (c *Client) GetIssue(ctx context.Context, issueKey string) (*cloud.Issue, e
 	return issue, nil
 }
 
+// GetIssueDescription retrieves the description of a Jira issue in Atlassian Document Format.
+// Server has no v3 API, so its plain text description is converted instead.
+func (c *Client) GetIssueDescription(ctx context.Context, issueKey string) (*ADFNode, error) {
+	if c.server {
+		issue, _, err := c.apiClient.Issue.Get(ctx, issueKey, &cloud.GetQueryOptions{Fields: "description"})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching description of %s: %w", issueKey, err)
+		}
+		if issue.Fields == nil {
+			return NewADFText(""), nil
+		}
+		return NewADFText(issue.Fields.Description), nil
+	}
+
+	req, err := c.apiClient.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/api/3/issue/%s?fields=description", issueKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	var result struct {
+		Fields struct {
+			Description *ADFNode `json:"description"`
+		} `json:"fields"`
+	}
+	resp, err := c.apiClient.Do(req, &result)
+	if err != nil {
+		return nil, cloud.NewJiraError(resp, err)
+	}
+
+	return result.Fields.Description, nil
+}
+
+// GetComments retrieves all the comments of a Jira issue with their body in Atlassian Document Format.
+// The plain text comments of Server are converted to it.
+func (c *Client) GetComments(ctx context.Context, issueKey string) (*CommentList, error) {
+	var allComments []Comment
+	startAt := 0
+	maxResults := 100
+	apiVersion := 3
+	if c.server {
+		apiVersion = 2
+	}
+
+	for {
+		req, err := c.apiClient.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/api/%d/issue/%s/comment?startAt=%d&maxResults=%d&orderBy=created", apiVersion, issueKey, startAt, maxResults), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		var page struct {
+			Comments []Comment `json:"comments"`
+			Total    int       `json:"total"`
+		}
+		resp, err := c.apiClient.Do(req, &page)
+		if err != nil {
+			return nil, cloud.NewJiraError(resp, err)
+		}
+
+		allComments = append(allComments, page.Comments...)
+
+		// Stop when all the comments have been fetched
+		if len(page.Comments) == 0 || len(allComments) >= page.Total {
+			break
+		}
+		startAt += len(page.Comments)
+	}
+
+	return NewCommentList(allComments, len(allComments), len(allComments)), nil
+}
+
+// AddComment adds a new comment with the given text to a Jira issue.
+func (c *Client) AddComment(ctx context.Context, issueKey, body string) (*cloud.Comment, error) {
+	comment, _, err := c.apiClient.Issue.AddComment(ctx, issueKey, &cloud.Comment{Body: body})
+	if err != nil {
+		return nil, fmt.Errorf("error adding comment to %s: %w", issueKey, err)
+	}
+
+	return comment, nil
+}
+
+// GetTransitions retrieves the workflow transitions available for a Jira issue, including their screen fields.
+func (c *Client) GetTransitions(ctx context.Context, issueKey string) (*TransitionList, error) {
+	req, err := c.apiClient.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/api/2/issue/%s/transitions?expand=transitions.fields", issueKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	var result struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	resp, err := c.apiClient.Do(req, &result)
+	if err != nil {
+		return nil, cloud.NewJiraError(resp, err)
+	}
+
+	for _, transition := range result.Transitions {
+		for id, field := range transition.Fields {
+			field.userKey = c.userKey()
+			transition.Fields[id] = field
+		}
+	}
+	return NewTransitionList(result.Transitions), nil
+}
+
+// DoTransition performs a workflow transition on a Jira issue, setting the given screen field values
+// (already converted with FieldMeta.Value) and adding an optional comment.
+func (c *Client) DoTransition(ctx context.Context, issueKey string, transition *Transition, fields map[string]any, comment string) error {
+	payload := map[string]any{
+		"transition": map[string]string{"id": transition.ID},
+	}
+	if len(fields) > 0 {
+		payload["fields"] = fields
+	}
+	if comment != "" {
+		payload["update"] = map[string]any{
+			"comment": []map[string]any{{"add": map[string]string{"body": comment}}},
+		}
+	}
+
+	_, err := c.apiClient.Issue.DoTransitionWithPayload(ctx, issueKey, payload)
+	if err != nil {
+		return fmt.Errorf("error transitioning %s to %s: %w", issueKey, transition.To.Name, err)
+	}
+
+	return nil
+}
+
+// GetWorklogs retrieves all the worklogs of a Jira issue together with its time tracking estimates.
+func (c *Client) GetWorklogs(ctx context.Context, issueKey string) (*WorklogList, error) {
+	allWorklogs, err := c.GetIssueWorklogs(ctx, issueKey, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	issue, _, err := c.apiClient.Issue.Get(ctx, issueKey, &cloud.GetQueryOptions{Fields: "timetracking"})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching time tracking of %s: %w", issueKey, err)
+	}
+
+	var timeTracking *cloud.TimeTracking
+	if issue.Fields != nil {
+		timeTracking = issue.Fields.TimeTracking
+	}
+	return NewWorklogList(allWorklogs, timeTracking, len(allWorklogs), len(allWorklogs)), nil
+}
+
+// GetIssueWorklogs retrieves the worklogs of a Jira issue, optionally only the ones started after the given time.
+func (c *Client) GetIssueWorklogs(ctx context.Context, issueKey string, startedAfter time.Time) ([]cloud.WorklogRecord, error) {
+	var allWorklogs []cloud.WorklogRecord
+	startAt := 0
+	maxResults := 100
+
+	for {
+		options := &cloud.GetWorklogsQueryOptions{StartAt: int64(startAt), MaxResults: int32(maxResults)}
+		if !startedAfter.IsZero() {
+			options.StartedAfter = startedAfter.UnixMilli()
+		}
+		page, resp, err := c.apiClient.Issue.GetWorklogs(ctx, issueKey, cloud.WithQueryOptions(options))
+		if err != nil {
+			return nil, fmt.Errorf("error fetching worklogs of %s: %w", issueKey, cloud.NewJiraError(resp, err))
+		}
+
+		allWorklogs = append(allWorklogs, page.Worklogs...)
+		if len(page.Worklogs) == 0 || len(allWorklogs) >= page.Total {
+			break
+		}
+		startAt += len(page.Worklogs)
+	}
+
+	return allWorklogs, nil
+}
+
+// AddWorklog logs the given number of seconds of work on a Jira issue, started at the given time.
+func (c *Client) AddWorklog(ctx context.Context, issueKey string, started time.Time, seconds int, comment string) (*cloud.WorklogRecord, error) {
+	startedTime := cloud.Time(started)
+	record := &cloud.WorklogRecord{
+		Started:          &startedTime,
+		TimeSpentSeconds: seconds,
+		Comment:          comment,
+	}
+
+	worklog, _, err := c.apiClient.Issue.AddWorklogRecord(ctx, issueKey, record)
+	if err != nil {
+		return nil, fmt.Errorf("error logging work on %s: %w", issueKey, err)
+	}
+
+	return worklog, nil
+}
+
+// GetCreateIssueTypes retrieves the issue types that can be created in a project.
+func (c *Client) GetCreateIssueTypes(ctx context.Context, projectKey string) ([]cloud.IssueType, error) {
+	var allTypes []cloud.IssueType
+	startAt := 0
+	maxResults := 50
+
+	for {
+		req, err := c.apiClient.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes?startAt=%d&maxResults=%d", projectKey, startAt, maxResults), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		// Cloud returns the types in "issueTypes" while Server/Data Center uses "values"
+		var page struct {
+			IssueTypes []cloud.IssueType `json:"issueTypes"`
+			Values     []cloud.IssueType `json:"values"`
+			Total      int               `json:"total"`
+		}
+		resp, err := c.apiClient.Do(req, &page)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching issue types of %s: %w", projectKey, cloud.NewJiraError(resp, err))
+		}
+
+		types := append(page.IssueTypes, page.Values...)
+		allTypes = append(allTypes, types...)
+		if len(types) == 0 || len(allTypes) >= page.Total {
+			break
+		}
+		startAt += len(types)
+	}
+
+	return allTypes, nil
+}
+
+// GetCreateMeta retrieves the fields available when creating an issue of the given type in a project.
+func (c *Client) GetCreateMeta(ctx context.Context, projectKey string, issueType cloud.IssueType) (*IssueCreateMeta, error) {
+	meta := &IssueCreateMeta{Project: projectKey, IssueType: issueType, Fields: make(map[string]FieldMeta)}
+	startAt := 0
+	maxResults := 50
+
+	for {
+		req, err := c.apiClient.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/api/2/issue/createmeta/%s/issuetypes/%s?startAt=%d&maxResults=%d", projectKey, issueType.ID, startAt, maxResults), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+
+		// Cloud returns the fields in "fields" while Server/Data Center uses "values"
+		var page struct {
+			Fields []FieldMeta `json:"fields"`
+			Values []FieldMeta `json:"values"`
+			Total  int         `json:"total"`
+		}
+		resp, err := c.apiClient.Do(req, &page)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching create metadata of %s: %w", projectKey, cloud.NewJiraError(resp, err))
+		}
+
+		fields := append(page.Fields, page.Values...)
+		for _, field := range fields {
+			field.userKey = c.userKey()
+			meta.Fields[field.FieldID] = field
+		}
+		if len(fields) == 0 || len(meta.Fields) >= page.Total {
+			break
+		}
+		startAt += len(fields)
+	}
+
+	return meta, nil
+}
+
+// CreateIssue creates a new Jira issue with the given fields payload.
+func (c *Client) CreateIssue(ctx context.Context, fields map[string]any) (*cloud.Issue, error) {
+	req, err := c.apiClient.NewRequest(ctx, http.MethodPost, "rest/api/2/issue", map[string]any{"fields": fields})
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	issue := new(cloud.Issue)
+	resp, err := c.apiClient.Do(req, issue)
+	if err != nil {
+		return nil, fmt.Errorf("error creating issue: %w", cloud.NewJiraError(resp, err))
+	}
+
+	return issue, nil
+}
+
+// GetFields retrieves all the system and custom issue fields defined in Jira.
+func (c *Client) GetFields(ctx context.Context) (*FieldList, error) {
+	fields, _, err := c.apiClient.Field.GetList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching fields: %w", err)
+	}
+
+	return NewFieldList(fields), nil
+}
+
+// GetEditMeta retrieves the fields that can be edited on a Jira issue.
+func (c *Client) GetEditMeta(ctx context.Context, issueKey string) (*IssueEditMeta, error) {
+	req, err := c.apiClient.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/api/2/issue/%s/editmeta", issueKey), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	var result struct {
+		Fields map[string]FieldMeta `json:"fields"`
+	}
+	resp, err := c.apiClient.Do(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching edit metadata of %s: %w", issueKey, cloud.NewJiraError(resp, err))
+	}
+
+	// The edit metadata is keyed by field ID but does not include it in each field
+	meta := &IssueEditMeta{Key: issueKey, Fields: make(map[string]FieldMeta)}
+	for id, field := range result.Fields {
+		field.FieldID = id
+		field.userKey = c.userKey()
+		meta.Fields[id] = field
+	}
+
+	return meta, nil
+}
+
+// EditIssue updates a Jira issue with the given payload of fields and update operations.
+func (c *Client) EditIssue(ctx context.Context, issueKey string, payload map[string]any) error {
+	req, err := c.apiClient.NewRequest(ctx, http.MethodPut, fmt.Sprintf("rest/api/2/issue/%s", issueKey), payload)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := c.apiClient.Do(req, nil)
+	if err != nil {
+		return fmt.Errorf("error editing %s: %w", issueKey, cloud.NewJiraError(resp, err))
+	}
+
+	return nil
+}
+
 // SearchIssuesWithPagination fetches issues based on a JQL query with pagination and applies a result limit.
 func (c *Client) SearchIssuesWithPagination(jql string, maxResults int) (*IssueList, error) {
 	var allIssues []cloud.Issue
@@ -53,7 +437,7 @@ func (c *Client) SearchIssuesWithPagination(jql string, maxResults int) (*IssueL
 
 	for {
 		// Fetch issues in pages of size pageSize
-		issueList, response, err := c.SearchIssues(jql, nextPageToken, pageSize)
+		issueList, next, err := c.SearchIssues(jql, nextPageToken, pageSize)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching issues with pagination: %w", err)
 		}
@@ -77,20 +461,26 @@ func (c *Client) SearchIssuesWithPagination(jql string, maxResults int) (*IssueL
 		}
 
 		// If the number of issues returned is less than the page size, stop the loop
-		if len(issueList.Issues) < pageSize {
+		if len(issueList.Issues) < pageSize || next == "" {
 			break
 		}
 
 		// Move to the next page
-		nextPageToken = response.NextPageToken
+		nextPageToken = next
 	}
 
 	// Return the combined issue list with the total count and max results
 	return NewIssueList(allIssues, len(allIssues), total), nil
 }
 
-// SearchIssues executes a JQL query to find issues in Jira.
-func (c *Client) SearchIssues(jql string, nextPageToken string, limit int) (*IssueList, *cloud.Response, error) {
+// SearchIssues executes a JQL query to find issues in Jira, returning a page of issues and
+// the token of the next one, empty on the last page. Cloud paginates with opaque tokens
+// while Server uses the index of the first issue.
+func (c *Client) SearchIssues(jql string, nextPageToken string, limit int) (*IssueList, string, error) {
+	if c.server {
+		return c.searchServerIssues(jql, nextPageToken, limit)
+	}
+
 	searchOptions := &cloud.SearchOptionsV2{
 		NextPageToken: nextPageToken,
 		MaxResults:    limit,
@@ -99,11 +489,55 @@ func (c *Client) SearchIssues(jql string, nextPageToken string, limit int) (*Iss
 
 	issues, response, err := c.apiClient.Issue.SearchV2JQL(context.Background(), jql, searchOptions)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error executing JQL query: %w", err)
+		return nil, "", fmt.Errorf("error executing JQL query: %w", err)
+	}
+
+	next := response.NextPageToken
+	if response.IsLast {
+		next = ""
 	}
 
 	// Create an IssueList
-	return NewIssueList(issues, response.MaxResults, response.Total), response, nil
+	return NewIssueList(issues, response.MaxResults, response.Total), next, nil
+}
+
+// searchServerIssues executes a JQL query with the search endpoint of Server, which pages
+// the results with startAt.
+func (c *Client) searchServerIssues(jql string, nextPageToken string, limit int) (*IssueList, string, error) {
+	startAt := 0
+	if nextPageToken != "" {
+		var err error
+		if startAt, err = strconv.Atoi(nextPageToken); err != nil {
+			return nil, "", fmt.Errorf("invalid page token %q", nextPageToken)
+		}
+	}
+
+	query := url.Values{}
+	query.Set("jql", jql)
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(limit))
+	query.Set("fields", "*all")
+	req, err := c.apiClient.NewRequest(context.Background(), http.MethodGet, "rest/api/2/search?"+query.Encode(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating request: %w", err)
+	}
+
+	var result struct {
+		StartAt    int           `json:"startAt"`
+		MaxResults int           `json:"maxResults"`
+		Total      int           `json:"total"`
+		Issues     []cloud.Issue `json:"issues"`
+	}
+	resp, err := c.apiClient.Do(req, &result)
+	if err != nil {
+		return nil, "", fmt.Errorf("error executing JQL query: %w", cloud.NewJiraError(resp, err))
+	}
+
+	next := ""
+	if end := result.StartAt + len(result.Issues); len(result.Issues) > 0 && end < result.Total {
+		next = strconv.Itoa(end)
+	}
+	return NewIssueList(result.Issues, result.MaxResults, result.Total), next, nil
 }
 
 // SearchIssuesByFilter retrieves issues using a pre-existing saved filter by its ID and returns an IssueList with pagination.
@@ -118,28 +552,21 @@ func (c *Client) SearchIssuesByFilter(filterID string, limit int) (*IssueList, e
 	// Iterate over pages of issues
 	nextPageToken := ""
 	for {
-		// Create the search options with pagination
-		searchOptions := &cloud.SearchOptionsV2{
-			NextPageToken: nextPageToken,
-			MaxResults:    fetchLimit,
-			Fields:        []string{"*all"},
-		}
-
 		// Execute the search with the saved filter
-		issues, response, err := c.apiClient.Issue.SearchV2JQL(context.Background(), jql, searchOptions)
+		issueList, next, err := c.SearchIssues(jql, nextPageToken, fetchLimit)
 		if err != nil {
 			return nil, fmt.Errorf("error executing JQL query with filter %s: %w", filterID, err)
 		}
 
 		// Append issues to the list
-		allIssues = append(allIssues, issues...)
+		allIssues = append(allIssues, issueList.Issues...)
 
 		// Check if there are more pages to fetch
-		if len(allIssues) >= limit || response.IsLast {
+		if len(allIssues) >= limit || next == "" {
 			break
 		}
 
-		nextPageToken = response.NextPageToken
+		nextPageToken = next
 	}
 
 	// Return the IssueList with the fetched issues and pagination details
@@ -154,10 +581,6 @@ func (c *Client) GetAllProjects() (*ProjectList, error) {
 		return nil, fmt.Errorf("error fetching projects: %w", err)
 	}
 
-	if response.Total > response.MaxResults {
-		fmt.Printf("\033[1;31mDisplaying first %d results of %d\033[0m\n", response.MaxResults, response.Total)
-	}
-
 	// Return the list of projects (accessing the Projects field from the ProjectList)
 	return NewProjectList(projectList, response.MaxResults, response.Total), nil
 }
@@ -168,9 +591,15 @@ func (c *Client) GetAllUsers() (*UserList, error) {
 	startAt := 0
 	maxResults := 1000 // Maximum number of results per request
 
+	// Server has no endpoint to list users, but its search matches all of them with "."
+	endpoint := "/rest/api/2/users?startAt=%d&maxResults=%d"
+	if c.server {
+		endpoint = "/rest/api/2/user/search?username=.&startAt=%d&maxResults=%d"
+	}
+
 	for {
 		// Prepare the request with pagination
-		req, err := c.apiClient.NewRequest(context.Background(), http.MethodGet, fmt.Sprintf("/rest/api/2/users?startAt=%d&maxResults=%d", startAt, maxResults), nil)
+		req, err := c.apiClient.NewRequest(context.Background(), http.MethodGet, fmt.Sprintf(endpoint, startAt, maxResults), nil)
 		if err != nil {
 			return nil, fmt.Errorf("error creating request: %w", err)
 		}
@@ -197,8 +626,102 @@ func (c *Client) GetAllUsers() (*UserList, error) {
 	return NewUserList(allUsers, maxResults, len(allUsers)), nil
 }
 
+// GetCurrentUser retrieves the Jira user the client is authenticated as.
+func (c *Client) GetCurrentUser(ctx context.Context) (*cloud.User, error) {
+	if c.server {
+		req, err := c.apiClient.NewRequest(ctx, http.MethodGet, "rest/api/2/myself", nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		user := new(cloud.User)
+		resp, err := c.apiClient.Do(req, user)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching current user: %w", cloud.NewJiraError(resp, err))
+		}
+		return user, nil
+	}
+
+	user, _, err := c.apiClient.User.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching current user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ServerInfo holds the deployment type and version of a Jira instance.
+type ServerInfo struct {
+	BaseURL        string `json:"baseUrl"`
+	Version        string `json:"version"`
+	DeploymentType string `json:"deploymentType"`
+	ServerTitle    string `json:"serverTitle"`
+}
+
+// GetServerInfo retrieves the deployment type and version of the Jira instance.
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	req, err := c.apiClient.NewRequest(ctx, http.MethodGet, "rest/api/2/serverInfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	info := new(ServerInfo)
+	resp, err := c.apiClient.Do(req, info)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching server info: %w", cloud.NewJiraError(resp, err))
+	}
+	return info, nil
+}
+
+// AssignIssue sets the assignee of a Jira issue by user ID (see UserID), or unassigns it when userID is empty.
+func (c *Client) AssignIssue(ctx context.Context, issueKey, userID string) error {
+	body := map[string]any{c.userKey(): nil}
+	if userID != "" {
+		body[c.userKey()] = userID
+	}
+
+	req, err := c.apiClient.NewRequest(ctx, http.MethodPut, fmt.Sprintf("rest/api/2/issue/%s/assignee", issueKey), body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := c.apiClient.Do(req, nil)
+	if err != nil {
+		return fmt.Errorf("error assigning %s: %w", issueKey, cloud.NewJiraError(resp, err))
+	}
+
+	return nil
+}
+
 // GetAllFilters retrieves all saved filters from Jira using the apiClient.
 func (c *Client) GetAllFilters() (*FilterList, error) {
+	// Server cannot search filters, so only the favourite ones are listed
+	if c.server {
+		req, err := c.apiClient.NewRequest(context.Background(), http.MethodGet, "rest/api/2/filter/favourite", nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		var filters []cloud.Filter
+		resp, err := c.apiClient.Do(req, &filters)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching filters: %w", cloud.NewJiraError(resp, err))
+		}
+
+		items := make([]cloud.FiltersListItem, 0, len(filters))
+		for _, filter := range filters {
+			items = append(items, cloud.FiltersListItem{
+				Self:        filter.Self,
+				ID:          filter.ID,
+				Name:        filter.Name,
+				Description: filter.Description,
+				Owner:       filter.Owner,
+				Jql:         filter.Jql,
+				ViewURL:     filter.ViewURL,
+				SearchURL:   filter.SearchURL,
+				Favourite:   filter.Favourite,
+			})
+		}
+		return NewFilterList(items, len(items), len(items)), nil
+	}
+
 	// Use the GetList method from apiClient.Filter to retrieve filters
 	filters, response, err := c.apiClient.Filter.Search(context.Background(), nil)
 	if err != nil {
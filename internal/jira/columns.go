@@ -0,0 +1,414 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// Schema types of the Jira Software custom fields used by the sprint and epic columns.
+const (
+	sprintFieldType   = "com.pyxis.greenhopper.jira:gh-sprint"
+	epicLinkFieldType = "com.pyxis.greenhopper.jira:gh-epic-link"
+)
+
+// storyPointsFields are the names of the story points field in company-managed and
+// team-managed projects.
+var storyPointsFields = []string{"Story Points", "Story point estimate"}
+
+// serverSprint matches the name and state of the sprints returned as strings by Jira Server.
+var serverSprint = regexp.MustCompile(`(?:state=([A-Z]+)|name=([^,\]]*))`)
+
+// IssueColumn is a column of the issue list, given by --columns. Its value is a string, a list
+// of strings, a number or a time, formatted depending on the output.
+type IssueColumn struct {
+	Name  string
+	value func(issue *cloud.Issue) any
+	style func(issue *cloud.Issue) theme.Style
+}
+
+// issueColumns are the columns built from the standard fields of an issue, by their
+// normalized name (see columnKey).
+var issueColumns = map[string]func(issue *cloud.Issue) any{
+	"key":     func(issue *cloud.Issue) any { return issue.Key },
+	"summary": func(issue *cloud.Issue) any { return issue.Fields.Summary },
+	"status": func(issue *cloud.Issue) any {
+		if issue.Fields.Status == nil {
+			return nil
+		}
+		return issue.Fields.Status.Name
+	},
+	"statuscategory": func(issue *cloud.Issue) any {
+		if issue.Fields.Status == nil {
+			return nil
+		}
+		return issue.Fields.Status.StatusCategory.Key
+	},
+	"type": func(issue *cloud.Issue) any { return issue.Fields.Type.Name },
+	"priority": func(issue *cloud.Issue) any {
+		if issue.Fields.Priority == nil {
+			return nil
+		}
+		return issue.Fields.Priority.Name
+	},
+	"assignee": func(issue *cloud.Issue) any {
+		return userName(issue.Fields.Assignee)
+	},
+	"assigneeemail": func(issue *cloud.Issue) any {
+		if issue.Fields.Assignee == nil {
+			return nil
+		}
+		return issue.Fields.Assignee.EmailAddress
+	},
+	"reporter":    func(issue *cloud.Issue) any { return userName(issue.Fields.Reporter) },
+	"project":     func(issue *cloud.Issue) any { return issue.Fields.Project.Key },
+	"projectname": func(issue *cloud.Issue) any { return issue.Fields.Project.Name },
+	"created":     func(issue *cloud.Issue) any { return time.Time(issue.Fields.Created) },
+	"updated":     func(issue *cloud.Issue) any { return time.Time(issue.Fields.Updated) },
+	"resolved":    func(issue *cloud.Issue) any { return time.Time(issue.Fields.Resolutiondate) },
+	"due":         func(issue *cloud.Issue) any { return time.Time(issue.Fields.Duedate) },
+	"resolution": func(issue *cloud.Issue) any {
+		if issue.Fields.Resolution == nil {
+			return nil
+		}
+		return issue.Fields.Resolution.Name
+	},
+	"labels": func(issue *cloud.Issue) any { return issue.Fields.Labels },
+	"components": func(issue *cloud.Issue) any {
+		var names []string
+		for _, component := range issue.Fields.Components {
+			names = append(names, component.Name)
+		}
+		return names
+	},
+	"fixversions": func(issue *cloud.Issue) any {
+		var names []string
+		for _, version := range issue.Fields.FixVersions {
+			names = append(names, version.Name)
+		}
+		return names
+	},
+	"parent": func(issue *cloud.Issue) any {
+		if issue.Fields.Parent == nil {
+			return nil
+		}
+		return issue.Fields.Parent.Key
+	},
+}
+
+// issueColumnStyles are the terminal styles of the columns highlighted in the text output.
+var issueColumnStyles = map[string]func(issue *cloud.Issue) theme.Style{
+	"key":      issueStatusStyle,
+	"status":   issueStatusStyle,
+	"assignee": func(*cloud.Issue) theme.Style { return theme.Subtle },
+	"project":  func(*cloud.Issue) theme.Style { return theme.Secondary },
+	"summary":  func(*cloud.Issue) theme.Style { return theme.Title },
+}
+
+// issueStatusStyle returns the style of the status category of an issue.
+func issueStatusStyle(issue *cloud.Issue) theme.Style {
+	if issue.Fields.Status == nil {
+		return theme.StatusStyle("")
+	}
+	return theme.StatusStyle(issue.Fields.Status.StatusCategory.Key)
+}
+
+// columnKey normalizes a column name, so "Fix Versions", "fix_versions" and "fixversions" match.
+func columnKey(name string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// ParseIssueColumns parses a comma separated list of columns: standard ones such as key,
+// status or assignee, sprint, storypoints, epic, or the name or ID of any other field.
+// loadFields is only called when a column needs the custom fields of the instance.
+func ParseIssueColumns(spec string, loadFields func() (*FieldList, error)) ([]IssueColumn, error) {
+	var columns []IssueColumn
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		column, err := parseIssueColumn(name, loadFields)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// parseIssueColumn returns the column of the given name.
+func parseIssueColumn(name string, loadFields func() (*FieldList, error)) (IssueColumn, error) {
+	key := columnKey(name)
+	column := IssueColumn{Name: name, style: issueColumnStyles[key]}
+	if value, ok := issueColumns[key]; ok {
+		column.value = value
+		return column, nil
+	}
+
+	fields, err := loadFields()
+	if err != nil {
+		return column, err
+	}
+	switch key {
+	case "sprint":
+		field := fields.findCustom(sprintFieldType)
+		if field == nil {
+			return column, fmt.Errorf("the Jira instance has no sprint field")
+		}
+		column.value = func(issue *cloud.Issue) any { return sprintName(issue.Fields.Unknowns[field.ID]) }
+		return column, nil
+
+	case "storypoints", "points":
+		var ids []string
+		for _, name := range storyPointsFields {
+			for _, field := range fields.match(name) {
+				ids = append(ids, field.ID)
+			}
+		}
+		if len(ids) == 0 {
+			return column, fmt.Errorf("the Jira instance has no story points field")
+		}
+		column.value = func(issue *cloud.Issue) any { return firstFieldValue(issue, ids) }
+		return column, nil
+
+	case "epic":
+		// Epics are linked with the Epic Link field in Server and with the parent in Cloud
+		var ids []string
+		if field := fields.findCustom(epicLinkFieldType); field != nil {
+			ids = append(ids, field.ID)
+		}
+		column.value = func(issue *cloud.Issue) any {
+			if value := firstFieldValue(issue, ids); value != nil {
+				return value
+			}
+			return issueColumns["parent"](issue)
+		}
+		return column, nil
+	}
+
+	field, err := fields.Find(name)
+	if err != nil {
+		return column, fmt.Errorf("invalid column: %w", err)
+	}
+	if value, ok := issueColumns[columnKey(field.ID)]; ok {
+		column.value = value
+		return column, nil
+	}
+	column.value = func(issue *cloud.Issue) any { return customFieldValue(issue.Fields.Unknowns[field.ID]) }
+	return column, nil
+}
+
+// Value returns the value of the column for the given issue.
+func (c IssueColumn) Value(issue *cloud.Issue) any {
+	if issue.Fields == nil {
+		issue = &cloud.Issue{Key: issue.Key, Fields: &cloud.IssueFields{}}
+	}
+	return c.value(issue)
+}
+
+// Text returns the value of the column for the given issue formatted for the terminal.
+func (c IssueColumn) Text(issue *cloud.Issue) string {
+	return formatColumnValue(c.Value(issue), false)
+}
+
+// Style returns the terminal style of the column for the given issue.
+func (c IssueColumn) Style(issue *cloud.Issue) theme.Style {
+	if c.style == nil || issue.Fields == nil {
+		return ""
+	}
+	return c.style(issue)
+}
+
+// firstFieldValue returns the first non empty value of the given custom fields of an issue.
+func firstFieldValue(issue *cloud.Issue, ids []string) any {
+	for _, id := range ids {
+		if value := customFieldValue(issue.Fields.Unknowns[id]); value != nil && value != "" {
+			return value
+		}
+	}
+	return nil
+}
+
+// customFieldValue converts the JSON value of a custom field to a column value, using the
+// name of the options, users and other objects.
+func customFieldValue(raw any) any {
+	switch value := raw.(type) {
+	case nil, string, float64:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case map[string]any:
+		for _, key := range []string{"displayName", "name", "value", "key"} {
+			if text, ok := value[key].(string); ok {
+				// Cascading selects hold the selected child option
+				if child, ok := value["child"].(map[string]any); ok {
+					text += " / " + formatColumnValue(customFieldValue(child), false)
+				}
+				return text
+			}
+		}
+		return nil
+	case []any:
+		var values []string
+		for _, item := range value {
+			if text := formatColumnValue(customFieldValue(item), false); text != "" {
+				values = append(values, text)
+			}
+		}
+		return values
+	}
+	return fmt.Sprint(raw)
+}
+
+// sprintName returns the name of the active sprint of the sprint field value, or the last one.
+// Cloud returns the sprints as objects and Server as strings with their attributes.
+func sprintName(raw any) any {
+	sprints, ok := raw.([]any)
+	if !ok || len(sprints) == 0 {
+		return nil
+	}
+
+	var name string
+	for _, sprint := range sprints {
+		var sprintName, state string
+		switch value := sprint.(type) {
+		case map[string]any:
+			sprintName, _ = value["name"].(string)
+			state, _ = value["state"].(string)
+		case string:
+			for _, match := range serverSprint.FindAllStringSubmatch(value, -1) {
+				if match[1] != "" {
+					state = match[1]
+				} else {
+					sprintName = match[2]
+				}
+			}
+		}
+		name = sprintName
+		if strings.EqualFold(state, "active") {
+			break
+		}
+	}
+	return name
+}
+
+// formatColumnValue formats a column value as text, with the timestamps in RFC3339 for
+// machine-readable outputs.
+func formatColumnValue(value any, machine bool) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if machine {
+			return formatTimestamp(v)
+		}
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format("02-01-2006")
+	}
+	return fmt.Sprint(value)
+}
+
+// jsonColumnValue returns a column value for JSON outputs, keeping lists and numbers.
+func jsonColumnValue(value any) any {
+	switch v := value.(type) {
+	case []string:
+		if v == nil {
+			return []string{}
+		}
+		return v
+	case float64, nil:
+		return v
+	}
+	return formatColumnValue(value, true)
+}
+
+// columnRecord is an issue with only the selected columns, written as a JSON object that
+// keeps the order of the columns.
+type columnRecord struct {
+	columns []IssueColumn
+	issue   *cloud.Issue
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r columnRecord) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range r.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(column.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(jsonColumnValue(column.Value(r.issue)))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// writeColumns writes the selected columns of the issues to w in a machine-readable format.
+func writeColumns(w io.Writer, format string, columns []IssueColumn, issues []cloud.Issue) error {
+	switch format {
+	case FormatJSON, FormatNDJSON:
+		records := make([]columnRecord, 0, len(issues))
+		for i := range issues {
+			records = append(records, columnRecord{columns: columns, issue: &issues[i]})
+		}
+		if format == FormatNDJSON {
+			encoder := json.NewEncoder(w)
+			for _, r := range records {
+				if err := encoder.Encode(r); err != nil {
+					return fmt.Errorf("error converting record to JSON: %w", err)
+				}
+			}
+			return nil
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error converting records to JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.Name)
+	}
+	rows := make([][]string, 0, len(issues))
+	for i := range issues {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, formatColumnValue(column.Value(&issues[i]), true))
+		}
+		rows = append(rows, row)
+	}
+	return writeTable(w, format, header, rows)
+}
@@ -0,0 +1,124 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// Comment is a Jira issue comment with its body in Atlassian Document Format.
+type Comment struct {
+	ID      string      `json:"id"`
+	Author  *cloud.User `json:"author"`
+	Body    *ADFNode    `json:"body"`
+	Created string      `json:"created"`
+	Updated string      `json:"updated"`
+}
+
+// CreatedTime returns the creation time of the comment.
+func (c *Comment) CreatedTime() time.Time {
+	return parseJiraTime(c.Created)
+}
+
+// CommentList holds a list of Jira comments and provides methods for displaying them.
+type CommentList struct {
+	Comments   []Comment
+	MaxResults int
+	Total      int
+}
+
+// NewCommentList initializes a new CommentList with a given slice of comments.
+func NewCommentList(comments []Comment, max, total int) *CommentList {
+	return &CommentList{Comments: comments, MaxResults: max, Total: total}
+}
+
+// Count returns the number of comments in the list.
+func (cl *CommentList) Count() int {
+	return len(cl.Comments)
+}
+
+// Print displays the comments on the console.
+func (cl *CommentList) Print() {
+	if len(cl.Comments) == 0 {
+		fmt.Println("No comments found.")
+		return
+	}
+
+	for i, comment := range cl.Comments {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fmt.Printf("%s %s\n", theme.Accent.Paint(userName(comment.Author)), theme.Secondary.Paintf("(%s)", formatRelativeTime(comment.CreatedTime())))
+		printIndented(comment.Body.Render(detailWidth-2), "  ")
+	}
+
+	if cl.Total > cl.MaxResults {
+		printTruncated(cl.MaxResults, cl.Total, "comments")
+	}
+}
+
+// Output writes the comments to the console using the given output format.
+func (cl *CommentList) Output(format string) error {
+	if format == "" || format == FormatText {
+		cl.Print()
+		return nil
+	}
+
+	records := make([]commentRecord, 0, len(cl.Comments))
+	for _, comment := range cl.Comments {
+		r := commentRecord{
+			ID:      comment.ID,
+			Author:  userName(comment.Author),
+			Created: formatTimestamp(comment.CreatedTime()),
+			Updated: formatTimestamp(parseJiraTime(comment.Updated)),
+			Body:    comment.Body.PlainText(),
+		}
+		if comment.Author != nil {
+			r.AuthorEmail = comment.Author.EmailAddress
+		}
+		records = append(records, r)
+	}
+	return writeRecords(os.Stdout, format, records)
+}
+
+// commentRecord is the flat representation of a comment used by machine-readable outputs.
+type commentRecord struct {
+	ID          string `json:"id"`
+	Author      string `json:"author"`
+	AuthorEmail string `json:"author_email"`
+	Created     string `json:"created"`
+	Updated     string `json:"updated"`
+	Body        string `json:"body"`
+}
+
+func (r commentRecord) columns() []string {
+	return []string{"id", "author", "author_email", "created", "updated", "body"}
+}
+
+func (r commentRecord) values() []string {
+	return []string{r.ID, r.Author, r.AuthorEmail, r.Created, r.Updated, r.Body}
+}
+
+// ToJSON converts the CommentList to a JSON representation.
+func (cl *CommentList) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(cl, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error converting comments to JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// parseJiraTime parses a timestamp as returned by the Jira REST API, returning zero time on failure.
+func parseJiraTime(value string) time.Time {
+	t, err := time.Parse("2006-01-02T15:04:05.000-0700", strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
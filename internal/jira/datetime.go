@@ -0,0 +1,145 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// dateTimeLayouts are the absolute date and time formats accepted by ParseDateTime.
+var dateTimeLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"02-01-2006 15:04",
+	"02-01-2006",
+}
+
+// ParseDateTime parses a human date and time relative to now in the local time zone.
+// Accepted values are "now", "today", "yesterday" and "tomorrow" optionally followed by
+// a time ("yesterday 15:00"), a time alone for today ("9:30") or an absolute date
+// such as "2024-05-01 10:00".
+func ParseDateTime(s string, now time.Time) (time.Time, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	if input == "" || input == "now" {
+		return now, nil
+	}
+
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	// Relative day with an optional time
+	day, clock, _ := strings.Cut(input, " ")
+	offset := 0
+	switch day {
+	case "today":
+	case "yesterday":
+		offset = -1
+	case "tomorrow":
+		offset = 1
+	default:
+		// A time alone refers to today
+		day, clock = "today", input
+	}
+
+	hour, minute := 0, 0
+	if clock = strings.TrimSpace(clock); clock != "" {
+		t, err := time.Parse("15:04", clock)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q, expected values like yesterday 15:00, 9:30 or 2024-05-01 10:00", s)
+		}
+		hour, minute = t.Hour(), t.Minute()
+	}
+
+	year, month, date := now.AddDate(0, 0, offset).Date()
+	return time.Date(year, month, date, hour, minute, 0, 0, now.Location()), nil
+}
+
+// jqlRelativeDate matches relative JQL dates such as "3d", "-2w" or "1w 2d".
+var jqlRelativeDate = regexp.MustCompile(`^([+-]?)((?:\d+\s*[wdhm]\s*)+)$`)
+
+// jqlRelativePart matches each component of a relative JQL date.
+var jqlRelativePart = regexp.MustCompile(`\d+\s*[wdhm]`)
+
+// jqlDateFunctions maps human date names to the JQL date functions.
+var jqlDateFunctions = map[string]string{
+	"today":      "startOfDay()",
+	"yesterday":  "startOfDay(-1)",
+	"tomorrow":   "startOfDay(1)",
+	"this week":  "startOfWeek()",
+	"last week":  "startOfWeek(-1)",
+	"next week":  "startOfWeek(1)",
+	"this month": "startOfMonth()",
+	"last month": "startOfMonth(-1)",
+	"next month": "startOfMonth(1)",
+	"this year":  "startOfYear()",
+	"last year":  "startOfYear(-1)",
+}
+
+// ParseJQLDate converts a human date into a JQL date expression. Durations such as "3d" or
+// "1w 2d" become relative dates in the past, or in the future when future is set, unless
+// they have an explicit sign. Names like "yesterday" or "this week" become JQL functions,
+// and weekdays ("last monday", "next friday") or absolute dates become quoted dates.
+func ParseJQLDate(s string, future bool, now time.Time) (string, error) {
+	input := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	if input == "" {
+		return "", fmt.Errorf("empty date")
+	}
+
+	if match := jqlRelativeDate.FindStringSubmatch(input); match != nil {
+		sign := match[1]
+		if sign == "" && !future {
+			sign = "-"
+		}
+		var parts []string
+		for _, part := range jqlRelativePart.FindAllString(match[2], -1) {
+			parts = append(parts, strings.ReplaceAll(part, " ", ""))
+		}
+		return QuoteJQL(sign + strings.Join(parts, " ")), nil
+	}
+
+	if function, ok := jqlDateFunctions[input]; ok {
+		return function, nil
+	}
+
+	if day, ok := parseWeekday(input, now); ok {
+		return QuoteJQL(day.Format("2006-01-02")), nil
+	}
+
+	t, err := ParseDateTime(input, now)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q, expected values like 3d, 1w 2d, yesterday, last monday or 2024-05-01", s)
+	}
+	if t.Equal(startOfDay(t)) {
+		return QuoteJQL(t.Format("2006-01-02")), nil
+	}
+	return QuoteJQL(t.Format("2006-01-02 15:04")), nil
+}
+
+// parseWeekday parses "monday", "last monday" or "next monday" into the date of the
+// closest such weekday before today (or after today, for "next").
+func parseWeekday(input string, now time.Time) (time.Time, bool) {
+	direction := -1
+	if rest, ok := strings.CutPrefix(input, "last "); ok {
+		input = rest
+	} else if rest, ok := strings.CutPrefix(input, "next "); ok {
+		input = rest
+		direction = 1
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.ToLower(weekday.String()) != input {
+			continue
+		}
+		day := startOfDay(now).AddDate(0, 0, direction)
+		for day.Weekday() != weekday {
+			day = day.AddDate(0, 0, direction)
+		}
+		return day, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,78 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Jira time tracking defaults: a working day has 8 hours and a working week 5 days.
+const (
+	workHoursPerDay = 8
+	workDaysPerWeek = 5
+)
+
+// workDurationPart matches a single component of a Jira duration, such as "1h" or "1.5d".
+var workDurationPart = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([wdhm])`)
+
+// workUnitSeconds maps each Jira duration unit to its length in seconds.
+var workUnitSeconds = map[string]float64{
+	"w": workDaysPerWeek * workHoursPerDay * 3600,
+	"d": workHoursPerDay * 3600,
+	"h": 3600,
+	"m": 60,
+}
+
+// ParseWorkDuration parses a Jira style duration such as "1h30m", "2d" or "1w 2d 4h" into seconds.
+func ParseWorkDuration(s string) (int, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	if input == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	seconds := 0.0
+	for input != "" {
+		match := workDurationPart.FindStringSubmatch(input)
+		if match == nil {
+			return 0, fmt.Errorf("invalid duration %q, expected values like 1h30m, 2d or 1w 2d", s)
+		}
+		value, _ := strconv.ParseFloat(match[1], 64)
+		seconds += value * workUnitSeconds[match[2]]
+		input = strings.TrimSpace(input[len(match[0]):])
+	}
+
+	if seconds < 60 {
+		return 0, fmt.Errorf("duration %q is shorter than a minute", s)
+	}
+	return int(seconds), nil
+}
+
+// FormatWorkDuration formats seconds as a Jira style duration such as "1d 2h 30m".
+func FormatWorkDuration(seconds int) string {
+	if seconds <= 0 {
+		return "0m"
+	}
+
+	var parts []string
+	minutes := seconds / 60
+	for _, unit := range []struct {
+		suffix  string
+		minutes int
+	}{
+		{"w", workDaysPerWeek * workHoursPerDay * 60},
+		{"d", workHoursPerDay * 60},
+		{"h", 60},
+		{"m", 1},
+	} {
+		if minutes >= unit.minutes {
+			parts = append(parts, fmt.Sprintf("%d%s", minutes/unit.minutes, unit.suffix))
+			minutes %= unit.minutes
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0m"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,81 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+// FieldList holds all the issue fields defined in Jira, used to resolve custom field display names.
+type FieldList struct {
+	Fields []cloud.Field
+}
+
+// NewFieldList initializes a new FieldList with a given slice of fields.
+func NewFieldList(fields []cloud.Field) *FieldList {
+	return &FieldList{Fields: fields}
+}
+
+// Count returns the number of fields in the list.
+func (fl *FieldList) Count() int {
+	return len(fl.Fields)
+}
+
+// Find returns the field matching the given ID, key, JQL clause name or case-insensitive display name.
+// Singular names of multi-value fields (label, fixVersion...) are accepted as well.
+func (fl *FieldList) Find(name string) (*cloud.Field, error) {
+	name = strings.TrimSpace(name)
+	matches := fl.match(name)
+	if len(matches) == 0 {
+		matches = fl.match(name + "s")
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown field %q", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	var ids []string
+	for _, field := range matches {
+		ids = append(ids, field.ID)
+	}
+	return nil, fmt.Errorf("%q matches several fields, use one of their IDs instead: %s", name, strings.Join(ids, ", "))
+}
+
+// match returns the fields matching the given name, or only the first one matching by ID or key.
+func (fl *FieldList) match(name string) []*cloud.Field {
+	var matches []*cloud.Field
+	for i, field := range fl.Fields {
+		if field.ID == name || field.Key == name {
+			return []*cloud.Field{&fl.Fields[i]}
+		}
+		if strings.EqualFold(field.Name, name) || containsFold(field.ClauseNames, name) {
+			matches = append(matches, &fl.Fields[i])
+		}
+	}
+	return matches
+}
+
+// findCustom returns the first custom field with the given schema type, such as the sprint
+// field of Jira Software, or nil if there is none.
+func (fl *FieldList) findCustom(schemaType string) *cloud.Field {
+	for i, field := range fl.Fields {
+		if field.Custom && field.Schema.Custom == schemaType {
+			return &fl.Fields[i]
+		}
+	}
+	return nil
+}
+
+// containsFold returns true if values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package jira
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+// FieldMeta describes an issue field shown in a create, edit or transition screen.
+type FieldMeta struct {
+	FieldID         string            `json:"fieldId"`
+	Name            string            `json:"name"`
+	Required        bool              `json:"required"`
+	HasDefaultValue bool              `json:"hasDefaultValue"`
+	Schema          cloud.FieldSchema `json:"schema"`
+	AllowedValues   []AllowedValue    `json:"allowedValues"`
+	Operations      []string          `json:"operations"`
+
+	// userKey is the key identifying users in the values, "accountId" when empty
+	userKey string
+}
+
+// Supports returns true if the field accepts the given edit operation (set, add, remove).
+// Fields without a list of operations are assumed to accept all of them.
+func (f FieldMeta) Supports(operation string) bool {
+	if len(f.Operations) == 0 {
+		return true
+	}
+	for _, op := range f.Operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedValue is one of the values accepted by a field.
+type AllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Key   string `json:"key"`
+}
+
+// Label returns the human readable name of the value.
+func (av AllowedValue) Label() string {
+	if av.Name != "" {
+		return av.Name
+	}
+	if av.Value != "" {
+		return av.Value
+	}
+	return av.Key
+}
+
+// Value converts the given user input into the JSON value expected by the field.
+// Array fields accept a comma separated list of values.
+func (f FieldMeta) Value(input string) (any, error) {
+	if f.Schema.Type != "array" {
+		return f.itemValue(strings.TrimSpace(input), f.Schema.Type)
+	}
+
+	values := []any{}
+	for _, item := range strings.Split(input, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		value, err := f.itemValue(item, f.Schema.Items)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// itemValue converts a single input value of the given schema type.
+func (f FieldMeta) itemValue(input, kind string) (any, error) {
+	// Fields with a fixed set of values are matched by name
+	if len(f.AllowedValues) > 0 {
+		var labels []string
+		for _, allowed := range f.AllowedValues {
+			if strings.EqualFold(allowed.Label(), input) || allowed.ID == input {
+				return map[string]string{"id": allowed.ID}, nil
+			}
+			labels = append(labels, allowed.Label())
+		}
+		return nil, fmt.Errorf("invalid value %q for %s, allowed values: %s", input, f.Name, strings.Join(labels, ", "))
+	}
+
+	switch kind {
+	case "number":
+		number, err := strconv.ParseFloat(input, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q for %s", input, f.Name)
+		}
+		return number, nil
+	case "user":
+		if f.userKey != "" {
+			return map[string]string{f.userKey: input}, nil
+		}
+		return map[string]string{"accountId": input}, nil
+	case "option":
+		return map[string]string{"value": input}, nil
+	case "issuelink", "issuelinks":
+		return map[string]string{"key": input}, nil
+	case "version", "component", "priority", "resolution":
+		return map[string]string{"name": input}, nil
+	}
+
+	return input, nil
+}
@@ -3,9 +3,11 @@ package jira
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
 
 	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
 )
 
 // FilterList holds a list of Jira filters and provides methods for displaying them.
@@ -33,17 +35,62 @@ func (fl *FilterList) Print() {
 	}
 
 	// Sort the filters by their Name
+	fl.sortByName()
+
+	t := newTable(
+		tableColumn{header: "ID"},
+		tableColumn{header: "NAME", flexible: true},
+	)
+	for _, filter := range fl.Filters {
+		t.addRow(tableCell{text: filter.ID, style: theme.Accent}, tableCell{text: filter.Name, style: theme.Secondary})
+	}
+	t.print()
+
+	if fl.Total > fl.MaxResults {
+		printTruncated(fl.MaxResults, fl.Total, "filters")
+	}
+}
+
+// sortByName sorts the filters by their Name
+func (fl *FilterList) sortByName() {
 	sort.Slice(fl.Filters, func(i, j int) bool {
 		return fl.Filters[i].Name < fl.Filters[j].Name
 	})
+}
 
-	for _, filter := range fl.Filters {
-		fmt.Printf("\033[1;34m%s\033[0m: \033[33m%s\033[0m\n", filter.ID, filter.Name)
+// Output writes the filters to the console using the given output format.
+func (fl *FilterList) Output(format string) error {
+	if format == "" || format == FormatText {
+		fl.Print()
+		return nil
 	}
 
-	if fl.Total > fl.MaxResults {
-		fmt.Printf("\033[1;32m * \033[1;31mDisplaying first %d of %d filters\033[0m\n", fl.MaxResults, fl.Total)
+	fl.sortByName()
+
+	records := make([]filterRecord, 0, len(fl.Filters))
+	for _, filter := range fl.Filters {
+		records = append(records, filterRecord{
+			ID:   filter.ID,
+			Name: filter.Name,
+			JQL:  filter.Jql,
+		})
 	}
+	return writeRecords(os.Stdout, format, records)
+}
+
+// filterRecord is the flat representation of a filter used by machine-readable outputs.
+type filterRecord struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	JQL  string `json:"jql"`
+}
+
+func (r filterRecord) columns() []string {
+	return []string{"id", "name", "jql"}
+}
+
+func (r filterRecord) values() []string {
+	return []string{r.ID, r.Name, r.JQL}
 }
 
 // ToJSON converts the FilterList to a JSON representation.
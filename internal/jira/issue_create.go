@@ -0,0 +1,72 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+// IssueCreateMeta holds the fields available when creating an issue of a given type in a project.
+type IssueCreateMeta struct {
+	Project   string
+	IssueType cloud.IssueType
+	Fields    map[string]FieldMeta
+}
+
+// Field returns the ID and metadata of the field matching the given ID or display name.
+func (m *IssueCreateMeta) Field(name string) (string, FieldMeta, error) {
+	if field, ok := m.Fields[name]; ok {
+		return name, field, nil
+	}
+	for id, field := range m.Fields {
+		if strings.EqualFold(field.Name, name) || strings.EqualFold(id, name) {
+			return id, field, nil
+		}
+	}
+	return "", FieldMeta{}, fmt.Errorf("field %q is not available when creating %s issues in %s", name, m.IssueType.Name, m.Project)
+}
+
+// Set converts the input for the given field and stores it in the fields payload.
+func (m *IssueCreateMeta) Set(fields map[string]any, name, input string) error {
+	id, field, err := m.Field(name)
+	if err != nil {
+		return err
+	}
+
+	value, err := field.Value(input)
+	if err != nil {
+		return err
+	}
+	fields[id] = value
+	return nil
+}
+
+// Validate checks that all the required fields without a default value are present in the payload.
+func (m *IssueCreateMeta) Validate(fields map[string]any) error {
+	var missing []string
+	for id, field := range m.Fields {
+		if _, ok := fields[id]; !ok && field.Required && !field.HasDefaultValue {
+			missing = append(missing, field.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("missing required fields for %s issues in %s: %s", m.IssueType.Name, m.Project, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// FindIssueType returns the issue type matching the given name from a list of types.
+func FindIssueType(types []cloud.IssueType, name string) (*cloud.IssueType, error) {
+	var names []string
+	for i, issueType := range types {
+		if strings.EqualFold(issueType.Name, name) || issueType.ID == name {
+			return &types[i], nil
+		}
+		names = append(names, issueType.Name)
+	}
+	return nil, fmt.Errorf("unknown issue type %q, available types: %s", name, strings.Join(names, ", "))
+}
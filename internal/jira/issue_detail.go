@@ -0,0 +1,202 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// detailWidth is the maximum width of wrapped text in the issue detail view.
+const detailWidth = 80
+
+// IssueDetail holds a single Jira issue and provides methods for displaying all its details.
+type IssueDetail struct {
+	Issue *cloud.Issue
+	URL   string
+
+	// Description in Atlassian Document Format, rendered instead of the plain text one when set
+	Description *ADFNode
+}
+
+// NewIssueDetail initializes a new IssueDetail for the given issue and browse URL.
+func NewIssueDetail(issue *cloud.Issue, url string) *IssueDetail {
+	return &IssueDetail{Issue: issue, URL: url}
+}
+
+// Print displays the issue details on the console.
+func (id *IssueDetail) Print() {
+	issue := id.Issue
+	fields := issue.Fields
+	if fields == nil {
+		fmt.Println(theme.Accent.Paint(issue.Key))
+		return
+	}
+
+	// Header with key, summary and link to the issue
+	fmt.Printf("%s %s\n", theme.Accent.Paint(issue.Key), theme.Title.Paint(fields.Summary))
+	if id.URL != "" {
+		fmt.Println(id.URL)
+	}
+	fmt.Println()
+
+	status := ""
+	if fields.Status != nil {
+		status = fields.Status.Name
+	}
+	priority := ""
+	if fields.Priority != nil {
+		priority = fields.Priority.Name
+	}
+	assignee := "Unassigned"
+	if fields.Assignee != nil {
+		assignee = fields.Assignee.DisplayName
+	}
+
+	printDetailField("Status", status)
+	printDetailField("Type", fields.Type.Name)
+	printDetailField("Priority", priority)
+	printDetailField("Project", fmt.Sprintf("%s (%s)", fields.Project.Name, fields.Project.Key))
+	printDetailField("Reporter", userName(fields.Reporter))
+	printDetailField("Assignee", assignee)
+	printDetailField("Labels", strings.Join(fields.Labels, ", "))
+
+	var components []string
+	for _, component := range fields.Components {
+		components = append(components, component.Name)
+	}
+	printDetailField("Components", strings.Join(components, ", "))
+
+	var versions []string
+	for _, version := range fields.FixVersions {
+		versions = append(versions, version.Name)
+	}
+	printDetailField("Fix Versions", strings.Join(versions, ", "))
+
+	printDetailField("Created", formatDetailTime(time.Time(fields.Created)))
+	printDetailField("Updated", formatDetailTime(time.Time(fields.Updated)))
+	printDetailField("Due", formatDetailDate(time.Time(fields.Duedate)))
+	printDetailField("Resolved", formatDetailTime(time.Time(fields.Resolutiondate)))
+
+	if tt := fields.TimeTracking; tt != nil {
+		printDetailField("Logged", tt.TimeSpent)
+		printDetailField("Estimate", tt.OriginalEstimate)
+		printDetailField("Remaining", tt.RemainingEstimate)
+	}
+
+	if fields.Parent != nil {
+		printDetailField("Parent", fields.Parent.Key)
+	}
+	if fields.Epic != nil {
+		printDetailField("Epic", fmt.Sprintf("%s %s", fields.Epic.Key, fields.Epic.Name))
+	}
+
+	// Subtasks of this issue
+	if len(fields.Subtasks) > 0 {
+		fmt.Println()
+		fmt.Println(theme.Title.Paint("Subtasks:"))
+		for _, subtask := range fields.Subtasks {
+			printLinkedIssue("", subtask.Key, &subtask.Fields)
+		}
+	}
+
+	// Links to other issues
+	if len(fields.IssueLinks) > 0 {
+		fmt.Println()
+		fmt.Println(theme.Title.Paint("Links:"))
+		for _, link := range fields.IssueLinks {
+			if link.OutwardIssue != nil {
+				printLinkedIssue(link.Type.Outward, link.OutwardIssue.Key, link.OutwardIssue.Fields)
+			}
+			if link.InwardIssue != nil {
+				printLinkedIssue(link.Type.Inward, link.InwardIssue.Key, link.InwardIssue.Fields)
+			}
+		}
+	}
+
+	// Issue description
+	fmt.Println()
+	fmt.Println(theme.Title.Paint("Description:"))
+	if !id.Description.IsEmpty() {
+		printIndented(id.Description.Render(detailWidth-2), "  ")
+		return
+	}
+	if strings.TrimSpace(fields.Description) == "" {
+		fmt.Println("  No description.")
+		return
+	}
+	printIndented(wrapText(fields.Description, detailWidth-2), "  ")
+}
+
+// Output writes the issue details to the console using the given output format.
+func (id *IssueDetail) Output(format string) error {
+	switch format {
+	case "", FormatText:
+		id.Print()
+		return nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(id.Issue, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error converting issue to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case FormatNDJSON:
+		return json.NewEncoder(os.Stdout).Encode(id.Issue)
+	}
+
+	return writeRecords(os.Stdout, format, []issueRecord{newIssueRecord(*id.Issue)})
+}
+
+// printDetailField prints a single aligned field of the detail view, skipping empty values.
+func printDetailField(name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Printf("  %s %s\n", theme.Secondary.Paintf("%-13s", name+":"), value)
+}
+
+// printLinkedIssue prints a one-line summary of a related issue.
+func printLinkedIssue(relation, key string, fields *cloud.IssueFields) {
+	if relation != "" {
+		relation += " "
+	}
+	if fields == nil {
+		fmt.Printf("  %s%s\n", relation, theme.Accent.Paint(key))
+		return
+	}
+
+	status := ""
+	if fields.Status != nil {
+		status = fields.Status.Name
+	}
+	fmt.Printf("  %s%s [%s] %s\n", relation, theme.Accent.Paint(key), status, fields.Summary)
+}
+
+// userName returns the display name of a user or an empty string if not present.
+func userName(user *cloud.User) string {
+	if user == nil {
+		return ""
+	}
+	return user.DisplayName
+}
+
+// formatDetailTime formats a timestamp for the detail view or returns an empty string if not set.
+func formatDetailTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("02-01-2006 15:04")
+}
+
+// formatDetailDate formats a date for the detail view or returns an empty string if not set.
+func formatDetailDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02-01-2006")
+}
@@ -0,0 +1,149 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IssueEditMeta holds the fields that can be edited on an issue.
+type IssueEditMeta struct {
+	Key    string
+	Fields map[string]FieldMeta
+}
+
+// IssueEdit collects the changes to apply to an issue, sending only the fields that were modified.
+type IssueEdit struct {
+	meta      *IssueEditMeta
+	allFields *FieldList
+
+	set    map[string]any
+	update map[string][]map[string]any
+}
+
+// NewIssueEdit initializes an empty IssueEdit for an issue, resolving field names with the given field list.
+func NewIssueEdit(meta *IssueEditMeta, allFields *FieldList) *IssueEdit {
+	return &IssueEdit{
+		meta:      meta,
+		allFields: allFields,
+		set:       make(map[string]any),
+		update:    make(map[string][]map[string]any),
+	}
+}
+
+// Field returns the ID and edit metadata of the field matching the given ID or display name.
+func (e *IssueEdit) Field(name string) (string, FieldMeta, error) {
+	name = strings.TrimSpace(name)
+	if field, ok := e.meta.Fields[name]; ok {
+		return name, field, nil
+	}
+
+	// Resolve display names (including custom fields) to their ID
+	id := ""
+	findErr := fmt.Errorf("unknown field %q", name)
+	if e.allFields != nil {
+		if field, err := e.allFields.Find(name); err == nil {
+			id = field.ID
+		} else {
+			findErr = err
+		}
+	}
+
+	// Fall back to the names of the editable fields, which also settles ambiguous names
+	if id == "" {
+		for fieldID, field := range e.meta.Fields {
+			if strings.EqualFold(field.Name, name) {
+				id = fieldID
+				break
+			}
+		}
+	}
+	if id == "" {
+		return "", FieldMeta{}, findErr
+	}
+
+	field, ok := e.meta.Fields[id]
+	if !ok {
+		return "", FieldMeta{}, fmt.Errorf("field %q (%s) is not editable on %s", name, id, e.meta.Key)
+	}
+	return id, field, nil
+}
+
+// Set replaces the value of a field with the given input.
+func (e *IssueEdit) Set(name, input string) error {
+	id, field, err := e.Field(name)
+	if err != nil {
+		return err
+	}
+	if !field.Supports("set") {
+		return fmt.Errorf("field %s does not support setting its value", field.Name)
+	}
+
+	value, err := field.Value(input)
+	if err != nil {
+		return err
+	}
+	e.set[id] = value
+	return nil
+}
+
+// Add adds the comma separated values of the input to a multi-value field.
+func (e *IssueEdit) Add(name, input string) error {
+	return e.modify("add", name, input)
+}
+
+// Remove removes the comma separated values of the input from a multi-value field.
+func (e *IssueEdit) Remove(name, input string) error {
+	return e.modify("remove", name, input)
+}
+
+// modify appends add or remove operations for each value of the input.
+func (e *IssueEdit) modify(operation, name, input string) error {
+	id, field, err := e.Field(name)
+	if err != nil {
+		return err
+	}
+	if field.Schema.Type != "array" || !field.Supports(operation) {
+		return fmt.Errorf("field %s does not support the %s operation", field.Name, operation)
+	}
+
+	value, err := field.Value(input)
+	if err != nil {
+		return err
+	}
+	for _, item := range value.([]any) {
+		e.update[id] = append(e.update[id], map[string]any{operation: item})
+	}
+	return nil
+}
+
+// IsEmpty returns true if no changes have been added.
+func (e *IssueEdit) IsEmpty() bool {
+	return len(e.set) == 0 && len(e.update) == 0
+}
+
+// Payload returns the request body for the edit endpoint.
+// Fields that are both set and modified are sent as update operations, since Jira
+// rejects requests that reference the same field in both sections.
+func (e *IssueEdit) Payload() map[string]any {
+	fields := make(map[string]any)
+	update := make(map[string][]map[string]any)
+	for id, operations := range e.update {
+		update[id] = operations
+	}
+	for id, value := range e.set {
+		if operations, ok := update[id]; ok {
+			update[id] = append([]map[string]any{{"set": value}}, operations...)
+			continue
+		}
+		fields[id] = value
+	}
+
+	payload := make(map[string]any)
+	if len(fields) > 0 {
+		payload["fields"] = fields
+	}
+	if len(update) > 0 {
+		payload["update"] = update
+	}
+	return payload
+}
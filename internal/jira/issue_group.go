@@ -0,0 +1,194 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// emptyGroupNames are the names of the groups of issues without a value, by column.
+var emptyGroupNames = map[string]string{
+	"assignee": "Unassigned",
+	"epic":     "No epic",
+	"sprint":   "No sprint",
+	"priority": "No priority",
+}
+
+// IssueGroup is a group of issues with the same value of the GroupBy column of a list.
+type IssueGroup struct {
+	Name   string
+	Issues []cloud.Issue
+}
+
+// Groups splits the issues by the value of the GroupBy column. Groups are sorted by name,
+// or by status category when grouping by status, with the issues without a value last.
+func (il *IssueList) Groups() []IssueGroup {
+	key := columnKey(il.GroupBy.Name)
+	emptyName, ok := emptyGroupNames[key]
+	if !ok {
+		emptyName = "None"
+	}
+
+	var groups []IssueGroup
+	index := make(map[string]int)
+	ranks := make(map[string]int)
+	for _, issue := range il.Issues {
+		name := il.GroupBy.Text(&issue)
+		rank := 0
+		if name == "" {
+			name = emptyName
+			rank = len(theme.StatusCategories) + 1
+		} else if key == "status" && issue.Fields != nil && issue.Fields.Status != nil {
+			// Statuses follow the board, from To Do to Done
+			rank = slices.Index(theme.StatusCategories, issue.Fields.Status.StatusCategory.Key) + 1
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			ranks[name] = rank
+			groups = append(groups, IssueGroup{Name: name})
+		}
+		groups[i].Issues = append(groups[i].Issues, issue)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if ranks[groups[i].Name] != ranks[groups[j].Name] {
+			return ranks[groups[i].Name] < ranks[groups[j].Name]
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// printGroups displays the issues on the console in sections with the name and size of
+// each group, aligned with each other.
+func (il *IssueList) printGroups() error {
+	if len(il.Issues) == 0 {
+		fmt.Println("No results found.")
+		return nil
+	}
+
+	groups := il.Groups()
+	if il.Template != nil {
+		for i, group := range groups {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(groupTitle(group))
+			if err := il.printTemplate(group.Issues); err != nil {
+				return err
+			}
+		}
+	} else {
+		t := il.newTable()
+		for _, group := range groups {
+			t.addSection(groupTitle(group))
+			for i := range group.Issues {
+				il.addRow(t, &group.Issues[i])
+			}
+		}
+		t.print()
+	}
+
+	if il.Total > il.MaxResults {
+		printTruncated(il.MaxResults, il.Total, "results")
+	}
+	return nil
+}
+
+// groupTitle returns the heading of a group of issues.
+func groupTitle(group IssueGroup) string {
+	return fmt.Sprintf("%s %s", theme.Title.Paint(group.Name), theme.Number.Paintf("(%d)", len(group.Issues)))
+}
+
+// issueGroupRecord is a group of issues in the JSON outputs, with the records of its issues.
+type issueGroupRecord struct {
+	Group  string `json:"group"`
+	Count  int    `json:"count"`
+	Issues any    `json:"issues"`
+}
+
+// outputGroups writes the groups of issues to the console using the given output format.
+// JSON outputs nest the issues in their groups and CSV and TSV add the group as first column.
+func (il *IssueList) outputGroups(format string) error {
+	switch format {
+	case "", FormatText:
+		return il.printGroups()
+
+	case FormatJSON, FormatNDJSON:
+		var records []issueGroupRecord
+		for _, group := range il.Groups() {
+			records = append(records, issueGroupRecord{Group: group.Name, Count: len(group.Issues), Issues: il.jsonRecords(group.Issues)})
+		}
+		if format == FormatNDJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			for _, r := range records {
+				if err := encoder.Encode(r); err != nil {
+					return fmt.Errorf("error converting record to JSON: %w", err)
+				}
+			}
+			return nil
+		}
+		if records == nil {
+			records = []issueGroupRecord{}
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error converting records to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	header := append([]string{"group"}, issueRecord{}.columns()...)
+	if il.Columns != nil {
+		header = []string{"group"}
+		for _, column := range il.Columns {
+			header = append(header, column.Name)
+		}
+	}
+	var rows [][]string
+	for _, group := range il.Groups() {
+		for i := range group.Issues {
+			rows = append(rows, append([]string{group.Name}, il.recordValues(&group.Issues[i])...))
+		}
+	}
+	return writeTable(os.Stdout, format, header, rows)
+}
+
+// jsonRecords returns the records of the given issues for the JSON outputs, with the
+// selected columns or the default ones.
+func (il *IssueList) jsonRecords(issues []cloud.Issue) any {
+	if il.Columns != nil {
+		records := make([]columnRecord, 0, len(issues))
+		for i := range issues {
+			records = append(records, columnRecord{columns: il.Columns, issue: &issues[i]})
+		}
+		return records
+	}
+
+	records := make([]issueRecord, 0, len(issues))
+	for _, issue := range issues {
+		records = append(records, newIssueRecord(issue))
+	}
+	return records
+}
+
+// recordValues returns the values of an issue for the CSV and TSV outputs.
+func (il *IssueList) recordValues(issue *cloud.Issue) []string {
+	if il.Columns == nil {
+		return newIssueRecord(*issue).values()
+	}
+	values := make([]string, 0, len(il.Columns))
+	for _, column := range il.Columns {
+		values = append(values, formatColumnValue(column.Value(issue), true))
+	}
+	return values
+}
@@ -3,16 +3,25 @@ package jira
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
 )
 
 // IssueList holds a list of Jira issues and provides methods for displaying them.
+// Columns and Template, when set, replace the default layout of the issues, and GroupBy
+// splits them in sections by the value of a column.
 type IssueList struct {
 	Issues     []cloud.Issue
 	MaxResults int
 	Total      int
+	Columns    []IssueColumn      `json:"-"`
+	Template   *template.Template `json:"-"`
+	GroupBy    *IssueColumn       `json:"-"`
 }
 
 // NewIssueList initializes a new IssueList with a given slice of issues.
@@ -27,65 +36,168 @@ func (il *IssueList) Count() int {
 
 // Print displays the issues on the console.
 func (il *IssueList) Print() {
-	var keyWidth, statusWidth int
-
 	// Check if there are issues
 	if len(il.Issues) == 0 {
 		fmt.Println("No results found.")
 		return
 	}
 
-	// Determine the maximum width for issue keys and statuses
-	for _, issue := range il.Issues {
-		if len(issue.Key) > keyWidth {
-			keyWidth = len(issue.Key)
+	t := il.newTable()
+	for i := range il.Issues {
+		il.addRow(t, &il.Issues[i])
+	}
+	t.print()
+
+	if il.Total > il.MaxResults {
+		printTruncated(il.MaxResults, il.Total, "results")
+	}
+}
+
+// newTable returns an empty table with the selected columns, or the default ones. All the
+// selected columns but the key are shortened when the table does not fit the terminal.
+func (il *IssueList) newTable() *table {
+	if il.Columns == nil {
+		return newTable(
+			tableColumn{header: "KEY"},
+			tableColumn{header: "STATUS"},
+			tableColumn{header: "UPDATED"},
+			tableColumn{header: "ASSIGNEE", flexible: true},
+			tableColumn{header: "PROJECT", flexible: true},
+			tableColumn{header: "SUMMARY", flexible: true},
+		)
+	}
+
+	columns := make([]tableColumn, 0, len(il.Columns))
+	for _, column := range il.Columns {
+		columns = append(columns, tableColumn{header: strings.ToUpper(column.Name), flexible: columnKey(column.Name) != "key"})
+	}
+	return newTable(columns...)
+}
+
+// addRow adds an issue to a table created by newTable.
+func (il *IssueList) addRow(t *table, issue *cloud.Issue) {
+	if il.Columns != nil {
+		cells := make([]tableCell, 0, len(il.Columns))
+		for _, column := range il.Columns {
+			cells = append(cells, tableCell{text: column.Text(issue), style: column.Style(issue)})
 		}
-		if len(issue.Fields.Status.Name) > statusWidth {
-			statusWidth = len(issue.Fields.Status.Name)
+		t.addRow(cells...)
+		return
+	}
+
+	// Color of the key and status based on the issue's status category
+	statusStyle := theme.StatusStyle(issue.Fields.Status.StatusCategory.Key)
+
+	// Set assignee to "Unassigned" if not present
+	assigneeName := "Unassigned"
+	if issue.Fields.Assignee != nil {
+		assigneeName = issue.Fields.Assignee.DisplayName
+	}
+
+	t.addRow(
+		tableCell{text: issue.Key, style: statusStyle},
+		tableCell{text: issue.Fields.Status.Name, style: statusStyle},
+		tableCell{text: time.Time(issue.Fields.Updated).Format("02-01-2006")},
+		tableCell{text: assigneeName, style: theme.Subtle},
+		tableCell{text: issue.Fields.Project.Name, style: theme.Secondary},
+		tableCell{text: issue.Fields.Summary, style: theme.Title},
+	)
+}
+
+// printTemplate executes the template with the given issues, ending the output of each one
+// with a newline.
+func (il *IssueList) printTemplate(issues []cloud.Issue) error {
+	for i := range issues {
+		var sb strings.Builder
+		if err := il.Template.Execute(&sb, &issues[i]); err != nil {
+			return fmt.Errorf("error formatting issue %s: %w", issues[i].Key, err)
 		}
+		text := sb.String()
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		fmt.Print(text)
 	}
+	return nil
+}
 
-	// Print each issue with proper formatting
+// Output writes the issues to the console using the given output format.
+func (il *IssueList) Output(format string) error {
+	if il.GroupBy != nil {
+		return il.outputGroups(format)
+	}
+	if il.Template != nil {
+		return il.printTemplate(il.Issues)
+	}
+	if format == "" || format == FormatText {
+		il.Print()
+		return nil
+	}
+	if il.Columns != nil {
+		return writeColumns(os.Stdout, format, il.Columns, il.Issues)
+	}
+
+	records := make([]issueRecord, 0, len(il.Issues))
 	for _, issue := range il.Issues {
-		// Default color for issue key
-		issueKeyColor := "\033[1;34m"
-
-		// Custom color based on the issue's status category
-		switch issue.Fields.Status.StatusCategory.Key {
-		case "new":
-			issueKeyColor = "\033[1;37m"
-		case "done":
-			issueKeyColor = "\033[1;32m"
-		}
+		records = append(records, newIssueRecord(issue))
+	}
+	return writeRecords(os.Stdout, format, records)
+}
 
-		// Set assignee to "Unassigned" if not present
-		assigneeName := "Unassigned"
-		if issue.Fields.Assignee != nil {
-			assigneeName = issue.Fields.Assignee.DisplayName
-		}
+// issueRecord is the flat representation of an issue used by machine-readable outputs.
+type issueRecord struct {
+	Key            string `json:"key"`
+	Status         string `json:"status"`
+	StatusCategory string `json:"status_category"`
+	Type           string `json:"type"`
+	Priority       string `json:"priority"`
+	Assignee       string `json:"assignee"`
+	AssigneeEmail  string `json:"assignee_email"`
+	Reporter       string `json:"reporter"`
+	Project        string `json:"project"`
+	ProjectName    string `json:"project_name"`
+	Created        string `json:"created"`
+	Updated        string `json:"updated"`
+	Summary        string `json:"summary"`
+}
 
-		// Format the updated time
-		updatedTime := time.Time(issue.Fields.Updated).Format("02-01-2006")
-
-		// Print formatted issue details
-		fmt.Printf(
-			"[%s%-*s\033[0m][%s%-*s\033[0m][%s][\033[34m%s\033[0m](\033[33m%s\033[0m)\033[1;37m %s\033[0m\n",
-			issueKeyColor,
-			keyWidth,
-			issue.Key,
-			issueKeyColor,
-			statusWidth,
-			issue.Fields.Status.Name,
-			updatedTime,
-			assigneeName,
-			issue.Fields.Project.Name,
-			issue.Fields.Summary,
-		)
+// newIssueRecord flattens the given issue into an issueRecord.
+func newIssueRecord(issue cloud.Issue) issueRecord {
+	r := issueRecord{Key: issue.Key}
+	fields := issue.Fields
+	if fields == nil {
+		return r
 	}
 
-	if il.Total > il.MaxResults {
-		fmt.Printf("\033[1;32m * \033[1;31mDisplaying first %d of %d results\033[0m\n", il.MaxResults, il.Total)
+	if fields.Status != nil {
+		r.Status = fields.Status.Name
+		r.StatusCategory = fields.Status.StatusCategory.Key
+	}
+	if fields.Priority != nil {
+		r.Priority = fields.Priority.Name
+	}
+	if fields.Assignee != nil {
+		r.Assignee = fields.Assignee.DisplayName
+		r.AssigneeEmail = fields.Assignee.EmailAddress
 	}
+	if fields.Reporter != nil {
+		r.Reporter = fields.Reporter.DisplayName
+	}
+	r.Type = fields.Type.Name
+	r.Project = fields.Project.Key
+	r.ProjectName = fields.Project.Name
+	r.Created = formatTimestamp(time.Time(fields.Created))
+	r.Updated = formatTimestamp(time.Time(fields.Updated))
+	r.Summary = fields.Summary
+	return r
+}
+
+func (r issueRecord) columns() []string {
+	return []string{"key", "status", "status_category", "type", "priority", "assignee", "assignee_email", "reporter", "project", "project_name", "created", "updated", "summary"}
+}
+
+func (r issueRecord) values() []string {
+	return []string{r.Key, r.Status, r.StatusCategory, r.Type, r.Priority, r.Assignee, r.AssigneeEmail, r.Reporter, r.Project, r.ProjectName, r.Created, r.Updated, r.Summary}
 }
 
 // ToJSON converts the IssueList to a JSON representation.
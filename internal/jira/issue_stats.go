@@ -0,0 +1,273 @@
+package jira
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// statsBarWidth is the width of the bar of a value with all the issues.
+const statsBarWidth = 40
+
+// DefaultStatsDimensions are the dimensions of --stats when --stats-by is not given.
+var DefaultStatsDimensions = []string{"category", "assignee", "type", "priority", "age"}
+
+// statusCategoryNames are the names of the status categories, in the order of the board.
+var statusCategoryNames = map[string]string{
+	"new":           "To Do",
+	"indeterminate": "In Progress",
+	"done":          "Done",
+}
+
+// ageBucket is a range of ages of the issues since they were created.
+type ageBucket struct {
+	name string
+	max  time.Duration
+}
+
+// ageBuckets are the ranges of the age dimension, from the newest issues to the oldest.
+var ageBuckets = []ageBucket{
+	{"< 1 week", 7 * 24 * time.Hour},
+	{"1-4 weeks", 28 * 24 * time.Hour},
+	{"1-3 months", 91 * 24 * time.Hour},
+	{"3-12 months", 365 * 24 * time.Hour},
+	{"> 1 year", math.MaxInt64},
+}
+
+// ageBucketNames returns the names of the age buckets, from the newest issues to the oldest.
+func ageBucketNames() []string {
+	names := make([]string, 0, len(ageBuckets))
+	for _, bucket := range ageBuckets {
+		names = append(names, bucket.name)
+	}
+	return names
+}
+
+// StatsDimension is a field the issues are counted by with --stats.
+type StatsDimension struct {
+	Name string
+	// title is the heading of the dimension in the text output
+	title string
+	// values returns the values of an issue, several for multi-value fields such as labels
+	values func(issue *cloud.Issue) []string
+	// order is the order of the values, sorted by count when not set
+	order []string
+}
+
+// statsDimensions are the dimensions not built from a column.
+var statsDimensions = map[string]StatsDimension{
+	"category": {
+		title: "Status category",
+		values: func(issue *cloud.Issue) []string {
+			if issue.Fields.Status == nil {
+				return nil
+			}
+			return []string{statusCategoryNames[issue.Fields.Status.StatusCategory.Key]}
+		},
+		order: []string{"To Do", "In Progress", "Done"},
+	},
+	"age": {
+		title: "Age",
+		values: func(issue *cloud.Issue) []string {
+			age := time.Since(time.Time(issue.Fields.Created))
+			for _, bucket := range ageBuckets {
+				if age < bucket.max {
+					return []string{bucket.name}
+				}
+			}
+			return nil
+		},
+		order: ageBucketNames(),
+	},
+}
+
+// ParseStatsDimensions parses the dimensions given with --stats-by: category, age or any
+// column accepted by --columns, such as assignee, sprint or labels.
+func ParseStatsDimensions(names []string, loadFields func() (*FieldList, error)) ([]StatsDimension, error) {
+	var dimensions []StatsDimension
+	for _, name := range splitValues(names) {
+		if dimension, ok := statsDimensions[columnKey(name)]; ok {
+			dimension.Name = name
+			dimensions = append(dimensions, dimension)
+			continue
+		}
+
+		column, err := parseIssueColumn(name, loadFields)
+		if err != nil {
+			return nil, err
+		}
+		emptyName, ok := emptyGroupNames[columnKey(name)]
+		if !ok {
+			emptyName = "None"
+		}
+		dimensions = append(dimensions, StatsDimension{
+			Name:  name,
+			title: strings.ToUpper(name[:1]) + name[1:],
+			values: func(issue *cloud.Issue) []string {
+				var values []string
+				switch value := column.Value(issue).(type) {
+				case []string:
+					values = value
+				default:
+					if text := formatColumnValue(value, false); text != "" {
+						values = []string{text}
+					}
+				}
+				if len(values) == 0 {
+					return []string{emptyName}
+				}
+				return values
+			},
+		})
+	}
+	if len(dimensions) == 0 {
+		return nil, fmt.Errorf("no dimensions given")
+	}
+	return dimensions, nil
+}
+
+// StatsCount is the number of issues with a value of a dimension.
+type StatsCount struct {
+	Value string
+	Count int
+}
+
+// StatsGroup holds the counts of the values of a dimension.
+type StatsGroup struct {
+	Dimension StatsDimension
+	Counts    []StatsCount
+}
+
+// IssueStats holds the number of issues of a list by the values of several dimensions, and
+// provides methods for displaying them.
+type IssueStats struct {
+	Groups     []StatsGroup
+	Count      int
+	MaxResults int
+	Total      int
+}
+
+// NewIssueStats counts the issues of the list by each of the given dimensions.
+func NewIssueStats(il *IssueList, dimensions []StatsDimension) *IssueStats {
+	stats := &IssueStats{Count: il.Count(), MaxResults: il.MaxResults, Total: il.Total}
+	for _, dimension := range dimensions {
+		counts := make(map[string]int)
+		for _, issue := range il.Issues {
+			if issue.Fields == nil {
+				issue.Fields = &cloud.IssueFields{}
+			}
+			for _, value := range dimension.values(&issue) {
+				counts[value]++
+			}
+		}
+
+		group := StatsGroup{Dimension: dimension}
+		for _, value := range dimension.order {
+			if counts[value] > 0 {
+				group.Counts = append(group.Counts, StatsCount{Value: value, Count: counts[value]})
+				delete(counts, value)
+			}
+		}
+		// Values out of the order, or all of them if there is none, go by count
+		var rest []StatsCount
+		for value, count := range counts {
+			rest = append(rest, StatsCount{Value: value, Count: count})
+		}
+		sort.Slice(rest, func(i, j int) bool {
+			if rest[i].Count != rest[j].Count {
+				return rest[i].Count > rest[j].Count
+			}
+			return rest[i].Value < rest[j].Value
+		})
+		group.Counts = append(group.Counts, rest...)
+		stats.Groups = append(stats.Groups, group)
+	}
+	return stats
+}
+
+// percent returns the share of the issues of the list with count issues.
+func (s *IssueStats) percent(count int) float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(count) * 100 / float64(s.Count)
+}
+
+// Print displays the counts of each dimension on the console, with a bar chart.
+func (s *IssueStats) Print() {
+	if s.Count == 0 {
+		fmt.Println("No results found.")
+		return
+	}
+
+	fmt.Printf("%s\n\n", theme.Number.Paint(Pluralize(s.Count, "issue")))
+	t := newTable(
+		tableColumn{flexible: true},
+		tableColumn{right: true},
+		tableColumn{right: true},
+		tableColumn{},
+	)
+	for _, group := range s.Groups {
+		t.addSection(theme.Title.Paint(group.Dimension.title))
+		for _, count := range group.Counts {
+			percent := s.percent(count.Count)
+			bar := int(math.Round(percent * statsBarWidth / 100))
+			t.addRow(
+				tableCell{text: count.Value, style: theme.Secondary},
+				tableCell{text: strconv.Itoa(count.Count), style: theme.Number},
+				tableCell{text: fmt.Sprintf("%.0f%%", percent)},
+				tableCell{text: strings.Repeat("#", max(bar, 1)), style: theme.Accent},
+			)
+		}
+	}
+	t.print()
+
+	if s.Total > s.MaxResults {
+		fmt.Println()
+		printTruncated(s.MaxResults, s.Total, "results")
+	}
+}
+
+// Output writes the counts to the console using the given output format.
+func (s *IssueStats) Output(format string) error {
+	if format == "" || format == FormatText {
+		s.Print()
+		return nil
+	}
+
+	var records []statsRecord
+	for _, group := range s.Groups {
+		for _, count := range group.Counts {
+			records = append(records, statsRecord{
+				Dimension: group.Dimension.Name,
+				Value:     count.Value,
+				Count:     count.Count,
+				Percent:   math.Round(s.percent(count.Count)*10) / 10,
+			})
+		}
+	}
+	return writeRecords(os.Stdout, format, records)
+}
+
+// statsRecord is the flat representation of a count used by machine-readable outputs.
+type statsRecord struct {
+	Dimension string  `json:"dimension"`
+	Value     string  `json:"value"`
+	Count     int     `json:"count"`
+	Percent   float64 `json:"percent"`
+}
+
+func (r statsRecord) columns() []string {
+	return []string{"dimension", "value", "count", "percent"}
+}
+
+func (r statsRecord) values() []string {
+	return []string{r.Dimension, r.Value, strconv.Itoa(r.Count), strconv.FormatFloat(r.Percent, 'f', -1, 64)}
+}
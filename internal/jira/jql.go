@@ -0,0 +1,34 @@
+package jira
+
+import "strings"
+
+// jqlEscaper escapes the characters that cannot appear as is inside a double quoted JQL string.
+var jqlEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// textSearchSpecial are the characters with a special meaning for the text search (~) operator.
+const textSearchSpecial = `+-&|!(){}[]^~*?\:"/`
+
+// QuoteJQL returns value as a double quoted JQL string literal. Quoting every value
+// also keeps user input from being parsed as JQL reserved words, functions or operators.
+func QuoteJQL(value string) string {
+	return `"` + jqlEscaper.Replace(value) + `"`
+}
+
+// QuoteJQLText returns value as a JQL string literal for the text search (~) operator,
+// escaping the special characters of the search syntax so they match literally.
+func QuoteJQLText(value string) string {
+	var sb strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(textSearchSpecial, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return QuoteJQL(sb.String())
+}
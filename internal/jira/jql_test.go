@@ -0,0 +1,196 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+
+	"irontec.com/jrquery/config"
+)
+
+func TestQuoteJQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "In Progress", `"In Progress"`},
+		{"empty", "", `""`},
+		{"double quotes", `say "hi"`, `"say \"hi\""`},
+		{"single quotes", "it's", `"it's"`},
+		{"backslash", `C:\temp`, `"C:\\temp"`},
+		{"backslash before quote", `a\"b`, `"a\\\"b"`},
+		{"trailing backslash", `a\`, `"a\\"`},
+		{"control characters", "a\nb\rc\td", `"a\nb\rc\td"`},
+		{"reserved word", "AND", `"AND"`},
+		{"reserved word lower case", "order", `"order"`},
+		{"empty keyword", "EMPTY", `"EMPTY"`},
+		{"function", "currentUser()", `"currentUser()"`},
+		{"injection", `x" OR project = "Y`, `"x\" OR project = \"Y"`},
+		{"operators", "a = b AND c != d", `"a = b AND c != d"`},
+		{"text search characters", "a+b-c*", `"a+b-c*"`},
+		{"unicode", "café ☕", `"café ☕"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuoteJQL(tt.value); got != tt.want {
+				t.Errorf("QuoteJQL(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteJQLText(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "login page", `"login page"`},
+		{"empty", "", `""`},
+		{"plus and minus", "c++ -flag", `"c\\+\\+ \\-flag"`},
+		{"boolean operators", "a && b || !c", `"a \\&\\& b \\|\\| \\!c"`},
+		{"grouping", "f(x) {y} [z]", `"f\\(x\\) \\{y\\} \\[z\\]"`},
+		{"boost and fuzzy", "a^2 b~", `"a\\^2 b\\~"`},
+		{"wildcards", "fo* ba?", `"fo\\* ba\\?"`},
+		{"colon and slash", "http://x/y", `"http\\:\\/\\/x\\/y"`},
+		{"double quotes", `"exact"`, `"\\\"exact\\\""`},
+		{"backslash", `C:\temp`, `"C\\:\\\\temp"`},
+		{"reserved word", "NOT", `"NOT"`},
+		{"control characters", "a\tb", `"a\tb"`},
+		{"unicode", "naïve ☕", `"naïve ☕"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuoteJQLText(tt.value); got != tt.want {
+				t.Errorf("QuoteJQLText(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildJQLQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       config.Flags
+		searchTerms []string
+		want        string
+	}{
+		{
+			name: "default",
+			want: "assignee = currentUser() AND statusCategory != 3 ORDER BY key ASC",
+		},
+		{
+			name:  "project",
+			flags: config.Flags{Project: `AB"C`},
+			want:  `project = "AB\"C" ORDER BY key ASC`,
+		},
+		{
+			name:  "status with reserved word",
+			flags: config.Flags{Status: "Done OR status = Open"},
+			want:  `status = "Done OR status = Open" ORDER BY key ASC`,
+		},
+		{
+			name:  "assignee",
+			flags: config.Flags{Username: `jane\doe`},
+			want:  `assignee = "jane\\doe" ORDER BY key ASC`,
+		},
+		{
+			name:  "assignee me",
+			flags: config.Flags{Username: "me"},
+			want:  `assignee = currentUser() ORDER BY key ASC`,
+		},
+		{
+			name:        "search summary",
+			flags:       config.Flags{Search: []bool{true}},
+			searchTerms: []string{"login", `"page"+`},
+			want:        `((summary ~ "login" AND summary ~ "\\\"page\\\"\\+")) ORDER BY key ASC`,
+		},
+		{
+			name:        "search summary and description",
+			flags:       config.Flags{Search: []bool{true, true}},
+			searchTerms: []string{"a:b"},
+			want:        `((summary ~ "a\\:b") OR (description ~ "a\\:b")) ORDER BY key ASC`,
+		},
+		{
+			name:        "search everywhere",
+			flags:       config.Flags{Search: []bool{true, true, true}},
+			searchTerms: []string{"x*"},
+			want:        `((summary ~ "x\\*") OR (description ~ "x\\*") OR (comment ~ "x\\*")) ORDER BY key ASC`,
+		},
+		{
+			name:  "single value",
+			flags: config.Flags{Type: []string{"Bug"}},
+			want:  `issuetype = "Bug" ORDER BY key ASC`,
+		},
+		{
+			name:  "several values",
+			flags: config.Flags{Label: []string{"a,b", "b", `c"d`}},
+			want:  `labels in ("a", "b", "c\"d") ORDER BY key ASC`,
+		},
+		{
+			name:  "reporter me",
+			flags: config.Flags{Reporter: []string{"me", "bob"}},
+			want:  `reporter in (currentUser(), "bob") ORDER BY key ASC`,
+		},
+		{
+			name:  "epic",
+			flags: config.Flags{Epic: []string{"ABC-1"}},
+			want:  `parent = "ABC-1" ORDER BY key ASC`,
+		},
+		{
+			name:  "custom query is not quoted",
+			flags: config.Flags{Query: `project = ABC`, Project: "XYZ"},
+			want:  `project = ABC`,
+		},
+		{
+			name:  "combined filters",
+			flags: config.Flags{Project: "ABC", Sprint: true, Unresolved: true, Priority: []string{"High"}, OrderByTime: []bool{true}},
+			want:  `project = "ABC" AND Sprint in openSprints() AND statusCategory != 3 AND priority = "High" ORDER BY updated DESC`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewQueryBuilder().BuildJQLQuery(&tt.flags, tt.searchTerms)
+			if err != nil {
+				t.Fatalf("BuildJQLQuery() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BuildJQLQuery() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildJQLQueryDates(t *testing.T) {
+	got, err := NewQueryBuilder().BuildJQLQuery(&config.Flags{CreatedSince: "2024-05-01", DueBefore: "2024-05-31"}, nil)
+	if err != nil {
+		t.Fatalf("BuildJQLQuery() error: %v", err)
+	}
+	for _, want := range []string{`created >= "2024-05-01`, `due <= "2024-05-31`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("BuildJQLQuery() = %s, want it to contain %s", got, want)
+		}
+	}
+
+	if _, err := NewQueryBuilder().BuildJQLQuery(&config.Flags{CreatedSince: `" OR 1=1`}, nil); err == nil {
+		t.Error("BuildJQLQuery() with an invalid date did not fail")
+	}
+}
+
+func TestAddFilter(t *testing.T) {
+	tests := []struct {
+		field    string
+		operator string
+		value    string
+		want     string
+	}{
+		{"status", "=", `Done" OR "x`, `status = "Done\" OR \"x"`},
+		{"summary", "~", "c++", `summary ~ "c\\+\\+"`},
+		{"summary", "!~", "a?", `summary !~ "a\\?"`},
+	}
+	for _, tt := range tests {
+		if got := NewQueryBuilder().AddFilter(tt.field, tt.operator, tt.value).Build(); got != tt.want {
+			t.Errorf("AddFilter(%q, %q, %q) = %s, want %s", tt.field, tt.operator, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package jira
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"golang.org/x/oauth2"
+)
+
+// Default endpoints of the Atlassian OAuth 2.0 (3LO) authorization.
+const (
+	OAuthAuthURL  = "https://auth.atlassian.com/authorize"
+	OAuthTokenURL = "https://auth.atlassian.com/oauth/token"
+	OAuthAPIURL   = "https://api.atlassian.com"
+)
+
+// OAuthScopes are the scopes requested by the OAuth 2.0 authorization. offline_access is
+// required to obtain a refresh token.
+var OAuthScopes = []string{"read:jira-work", "write:jira-work", "read:jira-user", "offline_access"}
+
+// OAuthResource is a Jira site the user granted access to in the OAuth 2.0 authorization.
+type OAuthResource struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// NewOAuthConfig returns the OAuth 2.0 configuration of an Atlassian app.
+func NewOAuthConfig(clientID, clientSecret, authURL, tokenURL, redirectURL string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   authURL,
+			TokenURL:  tokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: redirectURL,
+		Scopes:      OAuthScopes,
+	}
+}
+
+// OAuthLogin runs the authorization code flow with PKCE: it listens for the redirect on the
+// loopback address of the configured redirect URL, calls open with the authorization URL and
+// exchanges the received code for a token.
+func OAuthLogin(ctx context.Context, conf *oauth2.Config, open func(authURL string)) (*oauth2.Token, error) {
+	redirect, err := parseLoopbackURL(conf.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", redirect.host)
+	if err != nil {
+		return nil, fmt.Errorf("error listening for the OAuth callback: %w", err)
+	}
+	defer listener.Close()
+
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	// Wait for a single redirect with the authorization code
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != redirect.path {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		var res result
+		switch {
+		case query.Get("error") != "":
+			res.err = fmt.Errorf("authorization denied: %s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("state") != state:
+			res.err = errors.New("invalid state in the OAuth callback")
+		case query.Get("code") == "":
+			res.err = errors.New("missing code in the OAuth callback")
+		default:
+			res.code = query.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "jrquery is now authorized, you can close this window.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	open(conf.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("audience", "api.atlassian.com"),
+		oauth2.SetAuthURLParam("prompt", "consent"),
+		oauth2.S256ChallengeOption(verifier),
+	))
+
+	var res result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for the OAuth callback: %w", ctx.Err())
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	token, err := conf.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging the authorization code: %w", err)
+	}
+	return token, nil
+}
+
+// loopbackURL is the listen address and path of a redirect URL.
+type loopbackURL struct {
+	host string
+	path string
+}
+
+// parseLoopbackURL checks that the redirect URL points to the local machine.
+func parseLoopbackURL(redirectURL string) (*loopbackURL, error) {
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URL %q: %w", redirectURL, err)
+	}
+	host := u.Hostname()
+	if u.Scheme != "http" || (host != "localhost" && host != "127.0.0.1" && host != "::1") || u.Port() == "" {
+		return nil, fmt.Errorf("redirect URL %q must be http://localhost:PORT/...", redirectURL)
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	return &loopbackURL{host: net.JoinHostPort(host, u.Port()), path: path}, nil
+}
+
+// randomString returns a random URL safe string of n bytes of entropy.
+func randomString(n int) (string, error) {
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		return "", fmt.Errorf("error generating random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// GetOAuthResources retrieves the Jira sites accessible with an OAuth 2.0 token.
+func GetOAuthResources(ctx context.Context, apiURL string, token *oauth2.Token) ([]OAuthResource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(apiURL, "/")+"/oauth/token/accessible-resources", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	token.SetAuthHeader(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching accessible resources: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching accessible resources: %s", resp.Status)
+	}
+
+	var resources []OAuthResource
+	if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
+		return nil, fmt.Errorf("error decoding accessible resources: %w", err)
+	}
+	return resources, nil
+}
+
+// NewOAuthClient initializes a new Jira Cloud client authorized with an OAuth 2.0 token.
+// Requests go through the API gateway of the site with the given cloud ID, and the
+// access token is refreshed when expired, calling save with each new token.
+func NewOAuthClient(apiURL, cloudID string, conf *oauth2.Config, token *oauth2.Token, save func(*oauth2.Token) error) (*Client, error) {
+	if apiURL == "" || cloudID == "" || token == nil {
+		return nil, fmt.Errorf("apiURL, cloudID and token must be provided")
+	}
+
+	source := &savingTokenSource{
+		source: conf.TokenSource(context.Background(), token),
+		last:   token.AccessToken,
+		save:   save,
+	}
+	httpClient := &http.Client{Transport: &oauth2.Transport{Source: source}}
+
+	baseURL := fmt.Sprintf("%s/ex/jira/%s", strings.TrimSuffix(apiURL, "/"), cloudID)
+	apiClient, err := cloud.NewClient(baseURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira client: %w", err)
+	}
+
+	return &Client{apiClient: apiClient}, nil
+}
+
+// savingTokenSource saves the tokens of a refreshing token source when they change, as
+// Atlassian rotates the refresh token on every refresh.
+type savingTokenSource struct {
+	mu     sync.Mutex
+	source oauth2.TokenSource
+	last   string
+	save   func(*oauth2.Token) error
+}
+
+// Token implements the oauth2.TokenSource interface.
+func (s *savingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing OAuth token, run 'jrquery login --oauth' again: %w", err)
+	}
+	if token.AccessToken != s.last {
+		s.last = token.AccessToken
+		if err := s.save(token); err != nil {
+			return nil, fmt.Errorf("error saving refreshed OAuth token: %w", err)
+		}
+	}
+	return token, nil
+}
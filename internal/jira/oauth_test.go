@@ -0,0 +1,295 @@
+package jira
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// freeRedirectURL returns a loopback redirect URL on a free port.
+func freeRedirectURL(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return fmt.Sprintf("http://%s/callback", listener.Addr())
+}
+
+// writeToken writes a token response of the token endpoint.
+func writeToken(w http.ResponseWriter, access, refresh string, expiresIn int) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  access,
+		"refresh_token": refresh,
+		"token_type":    "Bearer",
+		"expires_in":    expiresIn,
+	})
+}
+
+func TestOAuthLogin(t *testing.T) {
+	var mu sync.Mutex
+	var challenge string
+	var exchanges int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		exchanges++
+
+		r.ParseForm()
+		for key, want := range map[string]string{
+			"grant_type":    "authorization_code",
+			"code":          "the-code",
+			"client_id":     "client",
+			"client_secret": "secret",
+		} {
+			if got := r.PostForm.Get(key); got != want {
+				t.Errorf("token request %s = %q, want %q", key, got, want)
+			}
+		}
+		// The verifier must match the challenge sent to the authorization URL
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if got := base64.RawURLEncoding.EncodeToString(sum[:]); got != challenge {
+			t.Errorf("code_verifier does not match the code_challenge %q", challenge)
+		}
+		writeToken(w, "access", "refresh", 3600)
+	}))
+	defer tokenServer.Close()
+
+	tests := []struct {
+		name     string
+		callback func(query url.Values) url.Values
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			callback: func(query url.Values) url.Values {
+				return url.Values{"code": {"the-code"}, "state": {query.Get("state")}}
+			},
+		},
+		{
+			name: "invalid state",
+			callback: func(query url.Values) url.Values {
+				return url.Values{"code": {"the-code"}, "state": {"forged"}}
+			},
+			wantErr: "invalid state",
+		},
+		{
+			name: "missing state",
+			callback: func(query url.Values) url.Values {
+				return url.Values{"code": {"the-code"}}
+			},
+			wantErr: "invalid state",
+		},
+		{
+			name: "denied",
+			callback: func(query url.Values) url.Values {
+				return url.Values{"error": {"access_denied"}, "state": {query.Get("state")}}
+			},
+			wantErr: "authorization denied: access_denied",
+		},
+		{
+			name: "missing code",
+			callback: func(query url.Values) url.Values {
+				return url.Values{"state": {query.Get("state")}}
+			},
+			wantErr: "missing code",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			exchanges = 0
+			mu.Unlock()
+
+			redirectURL := freeRedirectURL(t)
+			conf := NewOAuthConfig("client", "secret", "https://auth.example.net/authorize", tokenServer.URL, redirectURL)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			open := func(authURL string) {
+				u, err := url.Parse(authURL)
+				if err != nil {
+					t.Fatalf("invalid authorization URL: %v", err)
+				}
+				query := u.Query()
+				for key, want := range map[string]string{
+					"response_type":         "code",
+					"client_id":             "client",
+					"redirect_uri":          redirectURL,
+					"audience":              "api.atlassian.com",
+					"prompt":                "consent",
+					"code_challenge_method": "S256",
+				} {
+					if got := query.Get(key); got != want {
+						t.Errorf("authorization URL %s = %q, want %q", key, got, want)
+					}
+				}
+				if !strings.Contains(query.Get("scope"), "offline_access") {
+					t.Errorf("authorization URL scope = %q, want offline_access", query.Get("scope"))
+				}
+				if len(query.Get("state")) < 16 {
+					t.Errorf("authorization URL state = %q, want a random value", query.Get("state"))
+				}
+				mu.Lock()
+				challenge = query.Get("code_challenge")
+				mu.Unlock()
+
+				// Follow the redirect of the browser
+				resp, err := http.Get(redirectURL + "?" + tt.callback(query).Encode())
+				if err != nil {
+					t.Errorf("error calling the redirect URL: %v", err)
+					return
+				}
+				resp.Body.Close()
+				wantStatus := http.StatusOK
+				if tt.wantErr != "" {
+					wantStatus = http.StatusBadRequest
+				}
+				if resp.StatusCode != wantStatus {
+					t.Errorf("callback status = %d, want %d", resp.StatusCode, wantStatus)
+				}
+			}
+
+			token, err := OAuthLogin(ctx, conf, open)
+			mu.Lock()
+			defer mu.Unlock()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("OAuthLogin() error = %v, want %q", err, tt.wantErr)
+				}
+				if exchanges != 0 {
+					t.Errorf("code exchanged %d times after a failed callback", exchanges)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OAuthLogin() error: %v", err)
+			}
+			if token.AccessToken != "access" || token.RefreshToken != "refresh" {
+				t.Errorf("OAuthLogin() token = %q/%q, want access/refresh", token.AccessToken, token.RefreshToken)
+			}
+			if exchanges != 1 {
+				t.Errorf("code exchanged %d times, want 1", exchanges)
+			}
+		})
+	}
+}
+
+func TestOAuthLoginStateIsRandom(t *testing.T) {
+	conf := NewOAuthConfig("client", "secret", "https://auth.example.net/authorize", "https://auth.example.net/token", "")
+	seen := make(map[string]bool)
+	for range 3 {
+		conf.RedirectURL = freeRedirectURL(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		OAuthLogin(ctx, conf, func(authURL string) {
+			u, _ := url.Parse(authURL)
+			state, challenge := u.Query().Get("state"), u.Query().Get("code_challenge")
+			if seen[state] || seen[challenge] {
+				t.Errorf("state or code challenge reused in several logins")
+			}
+			seen[state], seen[challenge] = true, true
+			cancel()
+		})
+	}
+}
+
+func TestOAuthLoginRedirectURL(t *testing.T) {
+	for _, redirectURL := range []string{"https://localhost:8910/callback", "http://example.net:8910/callback", "http://localhost/callback"} {
+		conf := NewOAuthConfig("client", "secret", "", "", redirectURL)
+		if _, err := OAuthLogin(context.Background(), conf, func(string) { t.Error("authorization URL opened") }); err == nil {
+			t.Errorf("OAuthLogin() with redirect URL %q did not fail", redirectURL)
+		}
+	}
+}
+
+func TestSavingTokenSource(t *testing.T) {
+	// The token endpoint rotates the refresh token on every refresh and only accepts the
+	// last one, like Atlassian does. Tokens expire at once to refresh on every call.
+	var mu sync.Mutex
+	refreshes := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		r.ParseForm()
+		if got := r.PostForm.Get("grant_type"); got != "refresh_token" {
+			t.Errorf("grant_type = %q, want refresh_token", got)
+		}
+		if got, want := r.PostForm.Get("refresh_token"), fmt.Sprintf("refresh-%d", refreshes); got != want {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"error": "invalid_grant", "error_description": "got %s, want %s"}`, got, want)
+			return
+		}
+		refreshes++
+		writeToken(w, fmt.Sprintf("access-%d", refreshes), fmt.Sprintf("refresh-%d", refreshes), 1)
+	}))
+	defer tokenServer.Close()
+
+	conf := NewOAuthConfig("client", "secret", "", tokenServer.URL, "")
+	expired := &oauth2.Token{AccessToken: "access-0", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}
+	var saved []*oauth2.Token
+	source := &savingTokenSource{
+		source: conf.TokenSource(context.Background(), expired),
+		last:   expired.AccessToken,
+		save: func(token *oauth2.Token) error {
+			saved = append(saved, token)
+			return nil
+		},
+	}
+
+	for i := 1; i <= 3; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("Token() error: %v", err)
+		}
+		if want := fmt.Sprintf("access-%d", i); token.AccessToken != want {
+			t.Errorf("Token() = %q, want %q", token.AccessToken, want)
+		}
+		if len(saved) != i {
+			t.Fatalf("saved %d tokens after %d refreshes", len(saved), i)
+		}
+		if want := fmt.Sprintf("refresh-%d", i); saved[i-1].RefreshToken != want {
+			t.Errorf("saved refresh token = %q, want the rotated %q", saved[i-1].RefreshToken, want)
+		}
+	}
+}
+
+func TestSavingTokenSourceUnchanged(t *testing.T) {
+	valid := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)}
+	source := &savingTokenSource{
+		source: oauth2.StaticTokenSource(valid),
+		last:   valid.AccessToken,
+		save: func(*oauth2.Token) error {
+			t.Error("unchanged token saved")
+			return nil
+		},
+	}
+	if _, err := source.Token(); err != nil {
+		t.Fatalf("Token() error: %v", err)
+	}
+}
+
+func TestSavingTokenSourceSaveError(t *testing.T) {
+	source := &savingTokenSource{
+		source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "new"}),
+		last:   "old",
+		save:   func(*oauth2.Token) error { return fmt.Errorf("disk full") },
+	}
+	if _, err := source.Token(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Token() error = %v, want the save error", err)
+	}
+}
@@ -0,0 +1,94 @@
+package jira
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Output formats accepted by the --output flag.
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatTSV    = "tsv"
+)
+
+// record is a flat, machine-readable representation of a single list entry.
+type record interface {
+	// columns returns the field names, in the same order as values
+	columns() []string
+	// values returns the field values as strings for CSV/TSV output
+	values() []string
+}
+
+// writeRecords writes the given records to w in the requested machine-readable format.
+func writeRecords[T record](w io.Writer, format string, records []T) error {
+	switch format {
+	case FormatJSON:
+		// Always print an array, even when there are no records
+		if records == nil {
+			records = []T{}
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error converting records to JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, r := range records {
+			if err := encoder.Encode(r); err != nil {
+				return fmt.Errorf("error converting record to JSON: %w", err)
+			}
+		}
+		return nil
+
+	case FormatCSV, FormatTSV:
+		// Header line uses the same names as the JSON fields
+		var zero T
+		rows := make([][]string, 0, len(records))
+		for _, r := range records {
+			rows = append(rows, r.values())
+		}
+		return writeTable(w, format, zero.columns(), rows)
+	}
+
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+// writeTable writes a header and rows of values to w as CSV or TSV.
+func writeTable(w io.Writer, format string, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	switch format {
+	case FormatCSV:
+	case FormatTSV:
+		writer.Comma = '\t'
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatTimestamp returns the given time in RFC3339 or an empty string if it is not set.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -3,9 +3,11 @@ package jira
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
 
 	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
 )
 
 // ProjectList holds a list of Jira projects and provides methods for displaying them.
@@ -33,17 +35,71 @@ func (pl *ProjectList) Print() {
 	}
 
 	// Sort the projects by their Key
+	pl.sortByKey()
+
+	t := newTable(
+		tableColumn{header: "KEY"},
+		tableColumn{header: "NAME", flexible: true},
+		tableColumn{header: "TYPE"},
+		tableColumn{header: "CATEGORY", flexible: true},
+	)
+	for _, project := range pl.Projects {
+		t.addRow(
+			tableCell{text: project.Key, style: theme.Accent},
+			tableCell{text: project.Name, style: theme.Secondary},
+			tableCell{text: project.ProjectTypeKey},
+			tableCell{text: project.ProjectCategory.Name},
+		)
+	}
+	t.print()
+
+	if pl.Total > pl.MaxResults {
+		printTruncated(pl.MaxResults, pl.Total, "projects")
+	}
+}
+
+// sortByKey sorts the projects by their Key
+func (pl *ProjectList) sortByKey() {
 	sort.Slice(pl.Projects, func(i, j int) bool {
 		return (pl.Projects)[i].Key < (pl.Projects)[j].Key
 	})
+}
 
-	for _, project := range pl.Projects {
-		fmt.Printf("\033[1;34m%s\033[0m: \033[33m%s\033[0m (%s) [%s]\n", project.Key, project.Name, project.ProjectTypeKey, project.ProjectCategory.Name)
+// Output writes the projects to the console using the given output format.
+func (pl *ProjectList) Output(format string) error {
+	if format == "" || format == FormatText {
+		pl.Print()
+		return nil
 	}
 
-	if pl.Total > pl.MaxResults {
-		fmt.Printf("\033[1;32m * \033[1;31mDisplaying first %d of %d projects\033[0m\n", pl.MaxResults, pl.Total)
+	pl.sortByKey()
+
+	records := make([]projectRecord, 0, len(pl.Projects))
+	for _, project := range pl.Projects {
+		records = append(records, projectRecord{
+			Key:      project.Key,
+			Name:     project.Name,
+			Type:     project.ProjectTypeKey,
+			Category: project.ProjectCategory.Name,
+		})
 	}
+	return writeRecords(os.Stdout, format, records)
+}
+
+// projectRecord is the flat representation of a project used by machine-readable outputs.
+type projectRecord struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+}
+
+func (r projectRecord) columns() []string {
+	return []string{"key", "name", "type", "category"}
+}
+
+func (r projectRecord) values() []string {
+	return []string{r.Key, r.Name, r.Type, r.Category}
 }
 
 // ToJSON converts the ProjectList to a JSON representation.
@@ -3,23 +3,35 @@ package jira
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"irontec.com/jrquery/config"
 )
 
 // QueryBuilder is a helper struct to build flexible JQL queries.
 type QueryBuilder struct {
-	filters []string
+	filters   []string
+	epicField string
 }
 
 // NewQueryBuilder initializes a new QueryBuilder instance.
 func NewQueryBuilder() *QueryBuilder {
-	return &QueryBuilder{filters: make([]string, 0)}
+	return &QueryBuilder{filters: make([]string, 0), epicField: "parent"}
 }
 
-// AddFilter adds a JQL filter to the query.
+// SetEpicField sets the JQL field used by the epic filter, see Client.EpicField.
+func (qb *QueryBuilder) SetEpicField(field string) *QueryBuilder {
+	qb.epicField = field
+	return qb
+}
+
+// AddFilter adds a JQL filter to the query, quoting the value as a string literal.
 func (qb *QueryBuilder) AddFilter(field, operator, value string) *QueryBuilder {
-	qb.filters = append(qb.filters, fmt.Sprintf("%s %s \"%s\"", field, operator, value))
+	if operator == "~" || operator == "!~" {
+		qb.filters = append(qb.filters, fmt.Sprintf("%s %s %s", field, operator, QuoteJQLText(value)))
+		return qb
+	}
+	qb.filters = append(qb.filters, fmt.Sprintf("%s %s %s", field, operator, QuoteJQL(value)))
 	return qb
 }
 
@@ -29,17 +41,17 @@ func (qb *QueryBuilder) Build() string {
 }
 
 // BuildJQLQuery builds the JQL query from the command line flags
-func (qb *QueryBuilder) BuildJQLQuery(flags *config.Flags, searchTerms []string) string {
+func (qb *QueryBuilder) BuildJQLQuery(flags *config.Flags, searchTerms []string) (string, error) {
 	var jqlFields []string
 
 	// If custom query is provided, use it directly
 	if flags.Query != "" {
-		return flags.Query
+		return flags.Query, nil
 	}
 
 	// Add project filter if specified
 	if flags.Project != "" {
-		jqlFields = append(jqlFields, fmt.Sprintf("project = '%s'", flags.Project))
+		jqlFields = append(jqlFields, fmt.Sprintf("project = %s", QuoteJQL(flags.Project)))
 	}
 
 	// Add search conditions if provided
@@ -50,9 +62,9 @@ func (qb *QueryBuilder) BuildJQLQuery(flags *config.Flags, searchTerms []string)
 
 		// Loop over search terms and build conditions for summary, description, and comments
 		for _, term := range searchTerms {
-			summaryConditions = append(summaryConditions, fmt.Sprintf("summary ~ '%s'", term))
-			descriptionConditions = append(descriptionConditions, fmt.Sprintf("description ~ '%s'", term))
-			commentConditions = append(commentConditions, fmt.Sprintf("comment ~ '%s'", term))
+			summaryConditions = append(summaryConditions, fmt.Sprintf("summary ~ %s", QuoteJQLText(term)))
+			descriptionConditions = append(descriptionConditions, fmt.Sprintf("description ~ %s", QuoteJQLText(term)))
+			commentConditions = append(commentConditions, fmt.Sprintf("comment ~ %s", QuoteJQLText(term)))
 		}
 
 		// Combine conditions based on the search terms
@@ -70,9 +82,12 @@ func (qb *QueryBuilder) BuildJQLQuery(flags *config.Flags, searchTerms []string)
 		jqlFields = append(jqlFields, fmt.Sprintf("((%s))", strings.Join(searchConditions, ") OR (")))
 	}
 
-	// Filter by assignee if specified
-	if flags.Username != "" {
-		jqlFields = append(jqlFields, fmt.Sprintf("assignee = '%s'", flags.Username))
+	// Filter by assignee if specified. "me", also the default {{user}} of the presets,
+	// refers to the current user
+	if flags.Username == "me" {
+		jqlFields = append(jqlFields, "assignee = currentUser()")
+	} else if flags.Username != "" {
+		jqlFields = append(jqlFields, fmt.Sprintf("assignee = %s", QuoteJQL(flags.Username)))
 	}
 
 	// Only include issues with open sprints if the flag is set
@@ -87,7 +102,50 @@ func (qb *QueryBuilder) BuildJQLQuery(flags *config.Flags, searchTerms []string)
 
 	// Filter by status if specified
 	if flags.Status != "" {
-		jqlFields = append(jqlFields, fmt.Sprintf("status = '%s'", flags.Status))
+		jqlFields = append(jqlFields, fmt.Sprintf("status = %s", QuoteJQL(flags.Status)))
+	}
+
+	// Add the structured filters, each one with one or more values
+	structuredFilters := []struct {
+		field  string
+		values []string
+	}{
+		{"issuetype", flags.Type},
+		{"priority", flags.Priority},
+		{"labels", flags.Label},
+		{"component", flags.Component},
+		{"reporter", flags.Reporter},
+		{"fixVersion", flags.FixVersion},
+		{qb.epicField, flags.Epic},
+	}
+	for _, filter := range structuredFilters {
+		if clause := valuesClause(filter.field, splitValues(filter.values)); clause != "" {
+			jqlFields = append(jqlFields, clause)
+		}
+	}
+
+	// Add the date filters, relative to now
+	dateFilters := []struct {
+		field    string
+		operator string
+		value    string
+		future   bool
+	}{
+		{"created", ">=", flags.CreatedSince, false},
+		{"updated", ">=", flags.UpdatedSince, false},
+		{"resolved", ">=", flags.ResolvedSince, false},
+		{"due", "<=", flags.DueBefore, true},
+	}
+	now := time.Now()
+	for _, filter := range dateFilters {
+		if filter.value == "" {
+			continue
+		}
+		date, err := ParseJQLDate(filter.value, filter.future, now)
+		if err != nil {
+			return "", fmt.Errorf("%s filter: %w", filter.field, err)
+		}
+		jqlFields = append(jqlFields, fmt.Sprintf("%s %s %s", filter.field, filter.operator, date))
 	}
 
 	// Default filter if no filters are provided
@@ -114,5 +172,43 @@ func (qb *QueryBuilder) BuildJQLQuery(flags *config.Flags, searchTerms []string)
 	}
 
 	// Return the full JQL query with the ORDER BY clause
-	return fmt.Sprintf("%s %s", strings.Join(jqlFields, " AND "), orderBy)
+	return fmt.Sprintf("%s %s", strings.Join(jqlFields, " AND "), orderBy), nil
+}
+
+// valuesClause returns an equality clause for a single value or an "in" clause for several ones.
+// The "me" value of the reporter field refers to the current user.
+func valuesClause(field string, values []string) string {
+	var literals []string
+	for _, value := range values {
+		if field == "reporter" && value == "me" {
+			literals = append(literals, "currentUser()")
+			continue
+		}
+		literals = append(literals, QuoteJQL(value))
+	}
+
+	switch len(literals) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%s = %s", field, literals[0])
+	}
+	return fmt.Sprintf("%s in (%s)", field, strings.Join(literals, ", "))
+}
+
+// splitValues splits repeated and comma separated flag values, skipping empty and duplicated ones.
+func splitValues(flagValues []string) []string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, flagValue := range flagValues {
+		for _, value := range strings.Split(flagValue, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" || seen[value] {
+				continue
+			}
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	return values
 }
@@ -0,0 +1,190 @@
+package jira
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// minFlexibleWidth is the width flexible columns are never shortened below.
+const minFlexibleWidth = 12
+
+// tableWidth is the width of the terminal the tables are fitted to, or 0 to never shorten them.
+var tableWidth = terminalWidth()
+
+// terminalWidth returns the width of the terminal on stdout, or 0 if it is not a terminal.
+func terminalWidth() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// SetWide disables the shortening of the tables to the width of the terminal.
+func SetWide(wide bool) {
+	if wide {
+		tableWidth = 0
+	} else {
+		tableWidth = terminalWidth()
+	}
+}
+
+// tableColumn is a column of a table printed on the console.
+type tableColumn struct {
+	header string
+	// flexible columns are shortened with an ellipsis when the table does not fit the terminal
+	flexible bool
+	// right aligned columns are padded on the left, for numbers
+	right bool
+}
+
+// tableCell is the text of a column of a table row and its style.
+type tableCell struct {
+	text  string
+	style theme.Style
+}
+
+// tableSection is a heading printed before a group of rows of a table, starting at row start.
+type tableSection struct {
+	title string
+	start int
+}
+
+// table lays out rows of cells in aligned columns, fitted to the width of the terminal.
+// The rows can be split in sections, aligned with each other.
+type table struct {
+	columns  []tableColumn
+	rows     [][]tableCell
+	sections []tableSection
+}
+
+// newTable returns an empty table with the given columns.
+func newTable(columns ...tableColumn) *table {
+	return &table{columns: columns}
+}
+
+// addRow appends a row with a cell for each column.
+func (t *table) addRow(cells ...tableCell) {
+	t.rows = append(t.rows, cells)
+}
+
+// addSection starts a section of rows with the given heading.
+func (t *table) addSection(title string) {
+	t.sections = append(t.sections, tableSection{title: title, start: len(t.rows)})
+}
+
+// widths returns the width of each column, shortening the widest flexible ones until the
+// table fits in maxWidth, if it is not 0.
+func (t *table) widths(maxWidth int) []int {
+	widths := make([]int, len(t.columns))
+	for i, column := range t.columns {
+		widths[i] = visibleWidth(column.header)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], visibleWidth(cell.text))
+		}
+	}
+	if maxWidth <= 0 {
+		return widths
+	}
+
+	// Columns are separated by two spaces
+	total := 2 * (len(widths) - 1)
+	for _, width := range widths {
+		total += width
+	}
+	for total > maxWidth {
+		widest := -1
+		for i, column := range t.columns {
+			if column.flexible && widths[i] > minFlexibleWidth && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		shrink := min(total-maxWidth, widths[widest]-minFlexibleWidth)
+		// Shorten the widest column only down to the next one, to share the space between them
+		for i, column := range t.columns {
+			if column.flexible && i != widest && widths[i] < widths[widest] && widths[i] > minFlexibleWidth {
+				shrink = min(shrink, max(widths[widest]-widths[i], 1))
+			}
+		}
+		widths[widest] -= shrink
+		total -= shrink
+	}
+	return widths
+}
+
+// print writes the table to the console, with a header if the columns have one, repeated
+// below the heading of each section. Cells longer than their column are shortened with an
+// ellipsis.
+func (t *table) print() {
+	widths := t.widths(tableWidth)
+
+	var header []tableCell
+	for _, column := range t.columns {
+		if column.header != "" {
+			header = make([]tableCell, len(t.columns))
+			for i, column := range t.columns {
+				header[i] = tableCell{text: column.header, style: theme.Header}
+			}
+			break
+		}
+	}
+
+	sections := t.sections
+	if len(sections) == 0 {
+		sections = []tableSection{{start: 0}}
+	}
+	for i, section := range sections {
+		end := len(t.rows)
+		if i < len(sections)-1 {
+			end = sections[i+1].start
+		}
+		if section.title != "" {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(section.title)
+		}
+		if header != nil {
+			t.printRow(header, widths)
+		}
+		for _, row := range t.rows[section.start:end] {
+			t.printRow(row, widths)
+		}
+	}
+}
+
+// printRow writes a row of the table with the given column widths.
+func (t *table) printRow(row []tableCell, widths []int) {
+	var sb strings.Builder
+	for i, cell := range row {
+		text := truncateText(cell.text, widths[i])
+		padding := strings.Repeat(" ", widths[i]-visibleWidth(text))
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		if t.columns[i].right {
+			sb.WriteString(padding)
+		}
+		if text != "" {
+			sb.WriteString(cell.style.Paint(text))
+		}
+		// The last column is not padded to avoid trailing spaces
+		if !t.columns[i].right && i < len(row)-1 {
+			sb.WriteString(padding)
+		}
+	}
+	fmt.Println(strings.TrimRight(sb.String(), " "))
+}
@@ -0,0 +1,132 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// NewIssueTemplate parses a Go template executed with each issue (a *cloud.Issue) of a list,
+// given by --format. Besides the builtin functions of text/template, it provides:
+//
+//	field NAME ISSUE  value of a column or custom field, as in --columns
+//	user USER         display name of a user, empty if not set
+//	date LAYOUT TIME  time formatted with a Go layout such as "2006-01-02"
+//	ago TIME          how long ago the time was, such as "3 days ago"
+//	pad WIDTH TEXT    text padded to width characters, to the left if negative
+//	trunc WIDTH TEXT  text shortened to width characters with an ellipsis
+//	upper, lower      text in upper or lower case
+//	join SEP LIST     list of strings joined with sep
+//	default DEF TEXT  def when the text is empty
+//	json VALUE        value encoded as JSON
+//	color STYLE TEXT  text with a style such as "bold green", when colors are enabled
+//
+// loadFields is only called when a custom field is referenced.
+func NewIssueTemplate(text string, loadFields func() (*FieldList, error)) (*template.Template, error) {
+	columns := make(map[string]IssueColumn)
+	funcs := template.FuncMap{
+		"field": func(name string, issue *cloud.Issue) (any, error) {
+			column, ok := columns[name]
+			if !ok {
+				var err error
+				if column, err = parseIssueColumn(name, loadFields); err != nil {
+					return nil, err
+				}
+				columns[name] = column
+			}
+			if value := column.Value(issue); value != nil {
+				return value, nil
+			}
+			return "", nil
+		},
+		"user": userName,
+		"date": func(layout string, value any) string {
+			t, ok := templateTime(value)
+			if !ok || t.IsZero() {
+				return ""
+			}
+			return t.Local().Format(layout)
+		},
+		"ago": func(value any) string {
+			t, _ := templateTime(value)
+			return formatRelativeTime(t)
+		},
+		"pad": func(width int, value any) string {
+			text := templateText(value)
+			padding := strings.Repeat(" ", max(abs(width)-visibleWidth(text), 0))
+			if width < 0 {
+				return padding + text
+			}
+			return text + padding
+		},
+		"trunc": func(width int, value any) string { return truncateText(templateText(value), width) },
+		"upper": func(value any) string { return strings.ToUpper(templateText(value)) },
+		"lower": func(value any) string { return strings.ToLower(templateText(value)) },
+		"join":  func(sep string, values []string) string { return strings.Join(values, sep) },
+		"default": func(def string, value any) string {
+			if text := templateText(value); text != "" {
+				return text
+			}
+			return def
+		},
+		"json": func(value any) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+		"color": func(style string, value any) (string, error) {
+			s, err := theme.ParseStyle(style)
+			if err != nil {
+				return "", err
+			}
+			return s.Paint(templateText(value)), nil
+		},
+	}
+
+	tmpl, err := template.New("format").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format: %w", err)
+	}
+	return tmpl, nil
+}
+
+// templateTime converts the time values of the issue fields to a time.Time.
+func templateTime(value any) (time.Time, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		return t, true
+	case cloud.Time:
+		return time.Time(t), true
+	case cloud.Date:
+		return time.Time(t), true
+	case *cloud.Time:
+		if t != nil {
+			return time.Time(*t), true
+		}
+	case *cloud.Date:
+		if t != nil {
+			return time.Time(*t), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// templateText converts the values given to the template functions to text, like the columns.
+func templateText(value any) string {
+	if t, ok := templateTime(value); ok {
+		return formatColumnValue(t, false)
+	}
+	return formatColumnValue(value, false)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,161 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// wrapText splits text into lines of at most width characters, breaking on
+// whitespace and keeping the original paragraph breaks.
+func wrapText(text string, width int) []string {
+	var lines []string
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := words[0]
+		for _, word := range words[1:] {
+			if visibleWidth(line)+1+visibleWidth(word) > width {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+
+	// Remove trailing empty lines
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// printTruncated prints the notice shown below a list that only holds the first results.
+func printTruncated(shown, total int, what string) {
+	fmt.Printf("%s%s\n", theme.Success.Paint(" * "), theme.Failure.Paintf("Displaying first %d of %d %s", shown, total, what))
+}
+
+// printIndented prints the given lines prefixed with indent, leaving empty lines blank.
+func printIndented(lines []string, indent string) {
+	for _, line := range lines {
+		if line == "" {
+			fmt.Println()
+			continue
+		}
+		fmt.Printf("%s%s\n", indent, line)
+	}
+}
+
+// visibleWidth returns the number of terminal columns taken by s, counting wide characters
+// and emoji as two and ignoring terminal escape sequences.
+func visibleWidth(s string) int {
+	width := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case r == '\033':
+			inEscape = true
+		case inEscape:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+		default:
+			width += runewidth.RuneWidth(r)
+		}
+	}
+	return width
+}
+
+// truncateText shortens s to at most width terminal columns, ending it with an ellipsis.
+func truncateText(s string, width int) string {
+	if visibleWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	count := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case r == '\033':
+			inEscape = true
+		case inEscape:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+		default:
+			// Leave room for the ellipsis, without splitting wide characters
+			if count+runewidth.RuneWidth(r) > width-1 {
+				sb.WriteString("…" + theme.Reset.Code())
+				return sb.String()
+			}
+			count += runewidth.RuneWidth(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// stripEscapes removes all terminal escape sequences from s.
+func stripEscapes(s string) string {
+	var sb strings.Builder
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case r == '\033':
+			inEscape = true
+		case inEscape:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				inEscape = false
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// formatRelativeTime returns a human readable description of how long ago t happened.
+func formatRelativeTime(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+
+	elapsed := time.Since(t)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return Pluralize(int(elapsed.Minutes()), "minute") + " ago"
+	case elapsed < 24*time.Hour:
+		return Pluralize(int(elapsed.Hours()), "hour") + " ago"
+	case elapsed < 30*24*time.Hour:
+		return Pluralize(int(elapsed.Hours()/24), "day") + " ago"
+	case elapsed < 365*24*time.Hour:
+		return Pluralize(int(elapsed.Hours()/24/30), "month") + " ago"
+	}
+	return Pluralize(int(elapsed.Hours()/24/365), "year") + " ago"
+}
+
+// Pluralize returns the count followed by the unit, adding an "s" when needed.
+func Pluralize(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, unit)
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
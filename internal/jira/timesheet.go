@@ -0,0 +1,234 @@
+package jira
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// Timesheet aggregates the time a user logged on each issue and day of a date range.
+type Timesheet struct {
+	User string
+	From time.Time
+	To   time.Time
+	Rows []TimesheetRow
+}
+
+// TimesheetRow holds the time logged on an issue, in seconds per day of the range.
+type TimesheetRow struct {
+	Key     string
+	Summary string
+	Seconds []int
+}
+
+// NewTimesheet initializes an empty Timesheet between two dates, both included.
+func NewTimesheet(user string, from, to time.Time) *Timesheet {
+	return &Timesheet{User: user, From: startOfDay(from), To: startOfDay(to)}
+}
+
+// Days returns the dates of the timesheet range.
+func (ts *Timesheet) Days() []time.Time {
+	var days []time.Time
+	for day := ts.From; !day.After(ts.To); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// Add adds the worklogs of an issue authored by the given user and started within the range.
+// The user is identified by its account ID on Cloud or its username on Server.
+func (ts *Timesheet) Add(issue cloud.Issue, worklogs []cloud.WorklogRecord, userID string) {
+	days := ts.Days()
+	row := TimesheetRow{Key: issue.Key, Seconds: make([]int, len(days))}
+	if issue.Fields != nil {
+		row.Summary = issue.Fields.Summary
+	}
+
+	logged := false
+	for _, worklog := range worklogs {
+		if worklog.Started == nil || worklog.Author == nil || (worklog.Author.AccountID != userID && worklog.Author.Name != userID) {
+			continue
+		}
+		started := startOfDay(time.Time(*worklog.Started).In(ts.From.Location()))
+		for i, day := range days {
+			if day.Equal(started) {
+				row.Seconds[i] += worklog.TimeSpentSeconds
+				logged = true
+			}
+		}
+	}
+
+	if logged {
+		ts.Rows = append(ts.Rows, row)
+		sort.SliceStable(ts.Rows, func(i, j int) bool {
+			return compareIssueKeys(ts.Rows[i].Key, ts.Rows[j].Key) < 0
+		})
+	}
+}
+
+// Total returns the time logged on the row, in seconds.
+func (r TimesheetRow) Total() int {
+	return sum(r.Seconds)
+}
+
+// DayTotals returns the time logged on each day of the range, in seconds.
+func (ts *Timesheet) DayTotals() []int {
+	totals := make([]int, len(ts.Days()))
+	for _, row := range ts.Rows {
+		for i, seconds := range row.Seconds {
+			totals[i] += seconds
+		}
+	}
+	return totals
+}
+
+// Print displays the timesheet on the console as a grid of issues by day.
+func (ts *Timesheet) Print() {
+	fmt.Printf("%s\n\n", theme.Title.Paintf("Timesheet of %s from %s to %s", ts.User, ts.From.Format("02-01-2006"), ts.To.Format("02-01-2006")))
+	if len(ts.Rows) == 0 {
+		fmt.Println("No worklogs found.")
+		return
+	}
+
+	// Determine the maximum width for issue keys
+	keyWidth := len("Total")
+	for _, row := range ts.Rows {
+		keyWidth = max(keyWidth, len(row.Key))
+	}
+
+	// Header with the day names
+	days := ts.Days()
+	header := fmt.Sprintf("%-*s", keyWidth, "Issue")
+	for _, day := range days {
+		header += fmt.Sprintf(" %6s", day.Format("Mon 02"))
+	}
+	fmt.Println(theme.Title.Paintf("%s %7s", header, "Total"))
+
+	for _, row := range ts.Rows {
+		fmt.Print(theme.Accent.Paintf("%-*s", keyWidth, row.Key))
+		for _, seconds := range row.Seconds {
+			fmt.Printf(" %6s", formatClock(seconds))
+		}
+		fmt.Printf(" %s %s\n", theme.Number.Paintf("%7s", formatClock(row.Total())), row.Summary)
+	}
+
+	// Daily and weekly totals
+	totals := fmt.Sprintf("%-*s", keyWidth, "Total")
+	grandTotal := 0
+	for _, seconds := range ts.DayTotals() {
+		totals += fmt.Sprintf(" %6s", formatClock(seconds))
+		grandTotal += seconds
+	}
+	fmt.Printf("%s %s\n", theme.Title.Paint(totals), theme.Number.Paintf("%7s", formatClock(grandTotal)))
+}
+
+// Output writes the timesheet to the console using the given output format.
+// CSV and TSV keep the grid layout, with one column per day in hours.
+func (ts *Timesheet) Output(format string) error {
+	days := ts.Days()
+	switch format {
+	case "", FormatText:
+		ts.Print()
+		return nil
+
+	case FormatCSV, FormatTSV:
+		header := []string{"issue", "summary"}
+		for _, day := range days {
+			header = append(header, day.Format("2006-01-02"))
+		}
+		header = append(header, "total")
+
+		var rows [][]string
+		for _, row := range ts.Rows {
+			values := []string{row.Key, row.Summary}
+			for _, seconds := range row.Seconds {
+				values = append(values, formatHours(seconds))
+			}
+			rows = append(rows, append(values, formatHours(row.Total())))
+		}
+		return writeTable(os.Stdout, format, header, rows)
+	}
+
+	// JSON outputs have one record per issue and day with logged time
+	var records []timesheetRecord
+	for _, row := range ts.Rows {
+		for i, seconds := range row.Seconds {
+			if seconds == 0 {
+				continue
+			}
+			records = append(records, timesheetRecord{
+				Issue:   row.Key,
+				Summary: row.Summary,
+				Date:    days[i].Format("2006-01-02"),
+				Hours:   formatHours(seconds),
+				Seconds: seconds,
+			})
+		}
+	}
+	return writeRecords(os.Stdout, format, records)
+}
+
+// timesheetRecord is the flat representation of the time logged on an issue in a day.
+type timesheetRecord struct {
+	Issue   string `json:"issue"`
+	Summary string `json:"summary"`
+	Date    string `json:"date"`
+	Hours   string `json:"hours"`
+	Seconds int    `json:"seconds"`
+}
+
+func (r timesheetRecord) columns() []string {
+	return []string{"issue", "summary", "date", "hours", "seconds"}
+}
+
+func (r timesheetRecord) values() []string {
+	return []string{r.Issue, r.Summary, r.Date, r.Hours, strconv.Itoa(r.Seconds)}
+}
+
+// startOfDay returns the midnight of the day of t, in the same location.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// StartOfWeek returns the midnight of the Monday of the week of t.
+func StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+// formatClock formats seconds as hours and minutes (1:30), or a dash for zero.
+func formatClock(seconds int) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	minutes := seconds / 60
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// formatHours formats seconds as decimal hours for spreadsheets.
+func formatHours(seconds int) string {
+	return strconv.FormatFloat(float64(seconds)/3600, 'f', 2, 64)
+}
+
+// compareIssueKeys orders issue keys by project and then by number, so ABC-2 goes before ABC-10.
+func compareIssueKeys(a, b string) int {
+	projectA, numberA, _ := strings.Cut(a, "-")
+	projectB, numberB, _ := strings.Cut(b, "-")
+	if projectA != projectB {
+		return strings.Compare(projectA, projectB)
+	}
+
+	na, errA := strconv.Atoi(numberA)
+	nb, errB := strconv.Atoi(numberB)
+	if errA != nil || errB != nil {
+		return strings.Compare(numberA, numberB)
+	}
+	return na - nb
+}
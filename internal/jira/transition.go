@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// Transition is a workflow transition available for an issue, including its screen fields.
+type Transition struct {
+	ID     string               `json:"id"`
+	Name   string               `json:"name"`
+	To     cloud.Status         `json:"to"`
+	Fields map[string]FieldMeta `json:"fields"`
+}
+
+// RequiredFields returns the IDs of the required fields without a default value, sorted by name.
+func (t *Transition) RequiredFields() []string {
+	var required []string
+	for id, field := range t.Fields {
+		if field.Required && !field.HasDefaultValue {
+			required = append(required, id)
+		}
+	}
+	sort.Slice(required, func(i, j int) bool {
+		return t.Fields[required[i]].Name < t.Fields[required[j]].Name
+	})
+	return required
+}
+
+// TransitionList holds the transitions available for an issue and provides methods for displaying them.
+type TransitionList struct {
+	Transitions []Transition
+}
+
+// NewTransitionList initializes a new TransitionList with a given slice of transitions.
+func NewTransitionList(transitions []Transition) *TransitionList {
+	return &TransitionList{Transitions: transitions}
+}
+
+// Count returns the number of transitions in the list.
+func (tl *TransitionList) Count() int {
+	return len(tl.Transitions)
+}
+
+// Print displays the transitions on the console.
+func (tl *TransitionList) Print() {
+	if len(tl.Transitions) == 0 {
+		fmt.Println("No transitions available.")
+		return
+	}
+
+	for _, transition := range tl.Transitions {
+		fmt.Printf("%s → %s\n", theme.Accent.Paint(transition.Name), theme.Secondary.Paint(transition.To.Name))
+	}
+}
+
+// Find returns the transition whose name or target status matches the given name. Exact
+// case-insensitive matches of transition names are preferred, then those of status names
+// and then partial matches of either.
+func (tl *TransitionList) Find(name string) (*Transition, error) {
+	var byName, byStatus, partial []Transition
+
+	needle := strings.ToLower(strings.TrimSpace(name))
+	for _, transition := range tl.Transitions {
+		transitionName := strings.ToLower(transition.Name)
+		statusName := strings.ToLower(transition.To.Name)
+		switch {
+		case transitionName == needle:
+			byName = append(byName, transition)
+		case statusName == needle:
+			byStatus = append(byStatus, transition)
+		case strings.Contains(transitionName, needle) || strings.Contains(statusName, needle):
+			partial = append(partial, transition)
+		}
+	}
+
+	matches := byName
+	if len(matches) == 0 {
+		matches = byStatus
+	}
+	if len(matches) == 0 {
+		matches = partial
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &TransitionMatchError{Name: name, Candidates: tl}
+	case 1:
+		return &matches[0], nil
+	}
+	return nil, &TransitionMatchError{Name: name, Candidates: NewTransitionList(matches), Ambiguous: true}
+}
+
+// TransitionMatchError is returned when a transition name matches none or several transitions.
+type TransitionMatchError struct {
+	Name       string
+	Candidates *TransitionList
+	Ambiguous  bool
+}
+
+// Error implements the error interface.
+func (e *TransitionMatchError) Error() string {
+	var names []string
+	for _, transition := range e.Candidates.Transitions {
+		names = append(names, fmt.Sprintf("%s (→ %s)", transition.Name, transition.To.Name))
+	}
+
+	if e.Ambiguous {
+		return fmt.Sprintf("%q matches several transitions: %s", e.Name, strings.Join(names, ", "))
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("no transition matches %q, the issue has no available transitions", e.Name)
+	}
+	return fmt.Sprintf("no transition matches %q, available transitions: %s", e.Name, strings.Join(names, ", "))
+}
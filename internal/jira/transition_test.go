@@ -0,0 +1,56 @@
+package jira
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+func TestTransitionListFind(t *testing.T) {
+	transitions := NewTransitionList([]Transition{
+		{ID: "1", Name: "Start", To: cloud.Status{Name: "In Progress"}},
+		{ID: "2", Name: "Done", To: cloud.Status{Name: "Closed"}},
+		{ID: "3", Name: "Close", To: cloud.Status{Name: "Done"}},
+		{ID: "4", Name: "Reopen", To: cloud.Status{Name: "Open"}},
+		{ID: "5", Name: "Review", To: cloud.Status{Name: "In Review"}},
+	})
+
+	tests := []struct {
+		name          string
+		want          string
+		wantAmbiguous bool
+		wantNotFound  bool
+	}{
+		{name: "start", want: "1"},
+		{name: "  START ", want: "1"},
+		// The transition named Done wins over the one to the Done status
+		{name: "done", want: "2"},
+		{name: "closed", want: "2"},
+		// The status Open is an exact match, Reopen only a partial one
+		{name: "open", want: "4"},
+		{name: "in progress", want: "1"},
+		{name: "progr", want: "1"},
+		{name: "review", want: "5"},
+		{name: "in", wantAmbiguous: true},
+		{name: "deploy", wantNotFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transitions.Find(tt.name)
+			if tt.wantAmbiguous || tt.wantNotFound {
+				var matchErr *TransitionMatchError
+				if !errors.As(err, &matchErr) || matchErr.Ambiguous != tt.wantAmbiguous {
+					t.Fatalf("Find(%q) error = %v, want ambiguous %v", tt.name, err, tt.wantAmbiguous)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Find(%q) error: %v", tt.name, err)
+			}
+			if got.ID != tt.want {
+				t.Errorf("Find(%q) = %s (%s), want %s", tt.name, got.ID, got.Name, tt.want)
+			}
+		})
+	}
+}
@@ -3,9 +3,13 @@ package jira
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
 )
 
 // UserList holds a list of Jira users and provides methods for displaying them.
@@ -33,19 +37,116 @@ func (ul *UserList) Print() {
 	}
 
 	// Sort the users by their DisplayName
+	ul.sortByName()
+
+	t := newTable(
+		tableColumn{header: "EMAIL", flexible: true},
+		tableColumn{header: "NAME", flexible: true},
+	)
+	for _, user := range ul.Users {
+		if isActiveUser(user) {
+			t.addRow(tableCell{text: user.EmailAddress, style: theme.Accent}, tableCell{text: user.DisplayName, style: theme.Secondary})
+		}
+	}
+	t.print()
+
+	if ul.Total > ul.MaxResults {
+		printTruncated(ul.MaxResults, ul.Total, "users")
+	}
+}
+
+// sortByName sorts the users by their DisplayName
+func (ul *UserList) sortByName() {
 	sort.Slice(ul.Users, func(i, j int) bool {
 		return ul.Users[i].DisplayName < ul.Users[j].DisplayName
 	})
+}
+
+// Output writes the users to the console using the given output format.
+func (ul *UserList) Output(format string) error {
+	if format == "" || format == FormatText {
+		ul.Print()
+		return nil
+	}
+
+	ul.sortByName()
 
+	records := make([]userRecord, 0, len(ul.Users))
 	for _, user := range ul.Users {
-		if user.AccountType == "atlassian" && user.Active {
-			fmt.Printf("\033[1;34m%s\033[0m: \033[33m%s\033[0m\n", user.EmailAddress, user.DisplayName)
+		records = append(records, userRecord{
+			AccountID:   user.AccountID,
+			Username:    user.Name,
+			AccountType: user.AccountType,
+			Email:       user.EmailAddress,
+			DisplayName: user.DisplayName,
+			Active:      user.Active,
+		})
+	}
+	return writeRecords(os.Stdout, format, records)
+}
+
+// userRecord is the flat representation of a user used by machine-readable outputs.
+type userRecord struct {
+	AccountID   string `json:"account_id"`
+	Username    string `json:"username"`
+	AccountType string `json:"account_type"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	Active      bool   `json:"active"`
+}
+
+func (r userRecord) columns() []string {
+	return []string{"account_id", "username", "account_type", "email", "display_name", "active"}
+}
+
+func (r userRecord) values() []string {
+	return []string{r.AccountID, r.Username, r.AccountType, r.Email, r.DisplayName, strconv.FormatBool(r.Active)}
+}
+
+// isActiveUser returns true if the user is an active person, not an app or customer account
+// of Cloud. Server users have no account type.
+func isActiveUser(user cloud.User) bool {
+	return (user.AccountType == "" || user.AccountType == "atlassian") && user.Active
+}
+
+// Find returns the active user whose account ID, email or display name matches the given query.
+// Exact case-insensitive matches are preferred over partial ones.
+func (ul *UserList) Find(query string) (*cloud.User, error) {
+	var exact, partial []cloud.User
+
+	needle := strings.ToLower(strings.TrimSpace(query))
+	for _, user := range ul.Users {
+		if !isActiveUser(user) {
+			continue
+		}
+
+		email := strings.ToLower(user.EmailAddress)
+		name := strings.ToLower(user.DisplayName)
+		switch {
+		case user.AccountID == query || (user.Name != "" && user.Name == query) || email == needle || name == needle:
+			exact = append(exact, user)
+		case strings.Contains(email, needle) || strings.Contains(name, needle):
+			partial = append(partial, user)
 		}
 	}
 
-	if ul.Total > ul.MaxResults {
-		fmt.Printf("\033[1;32m * \033[1;31mDisplaying first %d of %d users\033[0m\n", ul.MaxResults, ul.Total)
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no user matches %q", query)
+	case 1:
+		return &matches[0], nil
+	}
+
+	var candidates []string
+	for _, user := range matches {
+		candidates = append(candidates, fmt.Sprintf("%s <%s>", user.DisplayName, user.EmailAddress))
 	}
+	return nil, fmt.Errorf("%q matches several users: %s", query, strings.Join(candidates, ", "))
 }
 
 // ToJSON converts the UserList to a JSON representation.
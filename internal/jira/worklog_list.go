@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andygrunwald/go-jira/v2/cloud"
+	"irontec.com/jrquery/internal/theme"
+)
+
+// WorklogList holds the worklogs of an issue with its time tracking estimates and provides methods for displaying them.
+type WorklogList struct {
+	Worklogs     []cloud.WorklogRecord
+	TimeTracking *cloud.TimeTracking
+	MaxResults   int
+	Total        int
+}
+
+// NewWorklogList initializes a new WorklogList with a given slice of worklogs and the issue time tracking.
+func NewWorklogList(worklogs []cloud.WorklogRecord, timeTracking *cloud.TimeTracking, max, total int) *WorklogList {
+	return &WorklogList{Worklogs: worklogs, TimeTracking: timeTracking, MaxResults: max, Total: total}
+}
+
+// Count returns the number of worklogs in the list.
+func (wl *WorklogList) Count() int {
+	return len(wl.Worklogs)
+}
+
+// TimeSpent returns the total time logged in the list, in seconds.
+func (wl *WorklogList) TimeSpent() int {
+	total := 0
+	for _, worklog := range wl.Worklogs {
+		total += worklog.TimeSpentSeconds
+	}
+	return total
+}
+
+// Print displays the worklogs, their total and the issue estimates on the console.
+func (wl *WorklogList) Print() {
+	if len(wl.Worklogs) == 0 {
+		fmt.Println("No worklogs found.")
+	}
+
+	// Determine the maximum width for author names and durations
+	var authorWidth, durationWidth int
+	for _, worklog := range wl.Worklogs {
+		authorWidth = max(authorWidth, visibleWidth(userName(worklog.Author)))
+		durationWidth = max(durationWidth, len(FormatWorkDuration(worklog.TimeSpentSeconds)))
+	}
+
+	for _, worklog := range wl.Worklogs {
+		fmt.Printf(
+			"[%s][%s][%s] %s\n",
+			formatWorklogTime(worklog.Started),
+			theme.Subtle.Paintf("%-*s", authorWidth, userName(worklog.Author)),
+			theme.Number.Paintf("%*s", durationWidth, FormatWorkDuration(worklog.TimeSpentSeconds)),
+			strings.Join(strings.Fields(worklog.Comment), " "),
+		)
+	}
+
+	if wl.Total > wl.MaxResults {
+		printTruncated(wl.MaxResults, wl.Total, "worklogs")
+	}
+
+	// Totals and estimates
+	fmt.Println()
+	printDetailField("Logged", fmt.Sprintf("%s (%s)", FormatWorkDuration(wl.TimeSpent()), Pluralize(len(wl.Worklogs), "worklog")))
+	if tt := wl.TimeTracking; tt != nil {
+		printDetailField("Estimate", formatEstimate(tt.OriginalEstimate))
+		printDetailField("Remaining", formatEstimate(tt.RemainingEstimate))
+	}
+}
+
+// Output writes the worklogs to the console using the given output format.
+func (wl *WorklogList) Output(format string) error {
+	if format == "" || format == FormatText {
+		wl.Print()
+		return nil
+	}
+
+	records := make([]worklogRecord, 0, len(wl.Worklogs))
+	for _, worklog := range wl.Worklogs {
+		r := worklogRecord{
+			ID:               worklog.ID,
+			Author:           userName(worklog.Author),
+			TimeSpent:        FormatWorkDuration(worklog.TimeSpentSeconds),
+			TimeSpentSeconds: worklog.TimeSpentSeconds,
+			Comment:          worklog.Comment,
+		}
+		if worklog.Author != nil {
+			r.AuthorEmail = worklog.Author.EmailAddress
+		}
+		if worklog.Started != nil {
+			r.Started = formatTimestamp(time.Time(*worklog.Started))
+		}
+		records = append(records, r)
+	}
+	return writeRecords(os.Stdout, format, records)
+}
+
+// worklogRecord is the flat representation of a worklog used by machine-readable outputs.
+type worklogRecord struct {
+	ID               string `json:"id"`
+	Author           string `json:"author"`
+	AuthorEmail      string `json:"author_email"`
+	Started          string `json:"started"`
+	TimeSpent        string `json:"time_spent"`
+	TimeSpentSeconds int    `json:"time_spent_seconds"`
+	Comment          string `json:"comment"`
+}
+
+func (r worklogRecord) columns() []string {
+	return []string{"id", "author", "author_email", "started", "time_spent", "time_spent_seconds", "comment"}
+}
+
+func (r worklogRecord) values() []string {
+	return []string{r.ID, r.Author, r.AuthorEmail, r.Started, r.TimeSpent, strconv.Itoa(r.TimeSpentSeconds), r.Comment}
+}
+
+// ToJSON converts the WorklogList to a JSON representation.
+func (wl *WorklogList) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(wl, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error converting worklogs to JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// formatWorklogTime formats the start time of a worklog.
+func formatWorklogTime(started *cloud.Time) string {
+	if started == nil {
+		return strings.Repeat(" ", 16)
+	}
+	return time.Time(*started).Local().Format("02-01-2006 15:04")
+}
+
+// formatEstimate returns the estimate as formatted by Jira or "None" when not set.
+func formatEstimate(estimate string) string {
+	if estimate == "" {
+		return "None"
+	}
+	return estimate
+}
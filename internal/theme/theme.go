@@ -0,0 +1,197 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Color modes accepted by the --color flag.
+const (
+	ModeAuto   = "auto"
+	ModeAlways = "always"
+	ModeNever  = "never"
+)
+
+// Style is a terminal text style, written as the parameters of an SGR escape sequence
+// such as "1;34" for bold blue.
+type Style string
+
+// Styles of the elements printed by jrquery.
+const (
+	// Accent highlights issue keys and the names of projects, users, filters and presets
+	Accent Style = "1;34"
+	// Title highlights summaries, headings and URLs
+	Title Style = "1;37"
+	// Header highlights the column headers of tables
+	Header Style = "1;4"
+	// Secondary marks values next to the main ones, such as dates and descriptions
+	Secondary Style = "33"
+	// Subtle marks less important values, such as assignees and authors
+	Subtle Style = "34"
+	// Number highlights durations and totals
+	Number Style = "1;33"
+	// Success marks completed operations
+	Success Style = "1;32"
+	// Failure marks errors and warnings about partial results
+	Failure Style = "1;31"
+	// Banner is the name of the tool in the version banner
+	Banner Style = "1;36"
+	// Code marks source code and inline code
+	Code Style = "36"
+	// Link marks URLs inside rich text
+	Link Style = "4;34"
+	// Bold, Dim, Italic, Underline and Strike are the plain text attributes
+	Bold      Style = "1"
+	Dim       Style = "2"
+	Italic    Style = "3"
+	Underline Style = "4"
+	Strike    Style = "9"
+	// Reset clears all the attributes
+	Reset Style = "0"
+)
+
+// statusStyles are the styles of the issue status categories, by their Jira key.
+var statusStyles = map[string]Style{
+	"new":           Title,
+	"indeterminate": Accent,
+	"done":          Success,
+}
+
+// StatusCategories are the keys of the Jira status categories whose style can be changed.
+var StatusCategories = []string{"new", "indeterminate", "done"}
+
+// colorNames maps the color names accepted by ParseStyle to their SGR foreground code.
+var colorNames = map[string]int{
+	"black":   30,
+	"red":     31,
+	"green":   32,
+	"yellow":  33,
+	"blue":    34,
+	"magenta": 35,
+	"cyan":    36,
+	"white":   37,
+	"default": 39,
+}
+
+// attributeNames maps the attribute names accepted by ParseStyle to their SGR code.
+var attributeNames = map[string]int{
+	"bold":      1,
+	"dim":       2,
+	"italic":    3,
+	"underline": 4,
+}
+
+// enabled is true when the styles are written to the output.
+var enabled = autoColor()
+
+// autoColor returns true if stdout is a terminal that supports colors and the user did not
+// disable them with NO_COLOR (https://no-color.org).
+func autoColor() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// SetMode enables or disables the styles: auto enables them only on color terminals,
+// always and never force them on or off.
+func SetMode(mode string) error {
+	switch mode {
+	case "", ModeAuto:
+		enabled = autoColor()
+	case ModeAlways:
+		enabled = true
+	case ModeNever:
+		enabled = false
+	default:
+		return fmt.Errorf("invalid color mode %q, use auto, always or never", mode)
+	}
+	return nil
+}
+
+// Enabled returns true if the styles are written to the output.
+func Enabled() bool {
+	return enabled
+}
+
+// Code returns the escape sequence of the style, or an empty string when styles are disabled.
+func (s Style) Code() string {
+	if !enabled || s == "" {
+		return ""
+	}
+	return "\033[" + string(s) + "m"
+}
+
+// Paint returns text with the style applied, followed by a reset of all the attributes.
+func (s Style) Paint(text string) string {
+	if !enabled || s == "" {
+		return text
+	}
+	return s.Code() + text + Reset.Code()
+}
+
+// Paintf formats according to a format specifier and returns the result with the style applied.
+func (s Style) Paintf(format string, args ...any) string {
+	return s.Paint(fmt.Sprintf(format, args...))
+}
+
+// Join returns a style combining the attributes of the given ones.
+func Join(styles ...Style) Style {
+	var parts []string
+	for _, s := range styles {
+		if s != "" {
+			parts = append(parts, string(s))
+		}
+	}
+	return Style(strings.Join(parts, ";"))
+}
+
+// StatusStyle returns the style of an issue status category, by its Jira key.
+func StatusStyle(category string) Style {
+	if style, ok := statusStyles[category]; ok {
+		return style
+	}
+	return Accent
+}
+
+// SetStatusStyle changes the style of an issue status category.
+func SetStatusStyle(category string, style Style) error {
+	if _, ok := statusStyles[category]; !ok {
+		return fmt.Errorf("unknown status category %q, use %s", category, strings.Join(StatusCategories, ", "))
+	}
+	statusStyles[category] = style
+	return nil
+}
+
+// ParseStyle parses a style given as color and attribute names, such as "bold green", or as
+// SGR parameters, such as "1;32".
+func ParseStyle(value string) (Style, error) {
+	var codes []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(value), func(r rune) bool { return r == ' ' || r == ',' || r == '+' }) {
+		if code, ok := attributeNames[word]; ok {
+			codes = append(codes, strconv.Itoa(code))
+			continue
+		}
+		if code, ok := colorNames[strings.TrimPrefix(word, "bright-")]; ok {
+			if strings.HasPrefix(word, "bright-") {
+				code += 60
+			}
+			codes = append(codes, strconv.Itoa(code))
+			continue
+		}
+		for _, param := range strings.Split(word, ";") {
+			if n, err := strconv.Atoi(param); err != nil || n < 0 || n > 255 {
+				return "", fmt.Errorf("invalid style %q, use color names such as 'bold green' or SGR codes such as '1;32'", value)
+			}
+		}
+		codes = append(codes, word)
+	}
+	if len(codes) == 0 {
+		return "", fmt.Errorf("empty style")
+	}
+	return Style(strings.Join(codes, ";")), nil
+}